@@ -0,0 +1,293 @@
+// ViChart library for Go
+// Author: Tad Vizbaras 
+// License: http://github.com/tadvi/vichart/blob/master/LICENSE 
+//
+package vichart
+
+import (
+	"fmt"
+	"github.com/ajstarks/svgo"
+	"math"
+	"time"
+)
+
+const (
+	legendGap = 20 // space after legend entry text
+)
+
+// Chart is implemented by every chart in the package so charts can be
+// kept in slices, registries and drawn polymorphically.
+type Chart interface {
+	// Validate checks that all required chart fields are set.
+	Validate() error
+	// SetDefaults sets sensible defaults for optional fields that are not set.
+	SetDefaults()
+	// Draw validates chart, resolves defaults and produces SVG document.
+	// Defaults are resolved for every draw and are not stored in chart, so
+	// chart follows changes of its Theme and of DefaultTheme.
+	Draw() error
+	// DrawAt draws chart with its top left corner at x, y into SVG document
+	// that is already started, so several charts can share one document.
+	DrawAt(x, y int) error
+	// SetCanvas points chart to canvas and sets its size, used by Dashboard
+	// to fit charts into grid cells.
+	SetCanvas(canvas *svg.SVG, width, height int)
+}
+
+// make sure all charts implement Chart interface and can be drawn by drawChart
+var (
+	_ Chart = (*HBarChart)(nil)
+	_ Chart = (*VBarChart)(nil)
+	_ Chart = (*VBMultiChart)(nil)
+	_ Chart = (*PieChart)(nil)
+	_ Chart = (*LineChart)(nil)
+	_ Chart = (*Dashboard)(nil)
+
+	_ drawer = (*HBarChart)(nil)
+	_ drawer = (*VBarChart)(nil)
+	_ drawer = (*VBMultiChart)(nil)
+	_ drawer = (*PieChart)(nil)
+	_ drawer = (*LineChart)(nil)
+	_ drawer = (*Dashboard)(nil)
+)
+
+// drawer is chart drawn by drawChart and drawChartAt, so Draw and DrawAt
+// are not repeated for every chart.
+type drawer interface {
+	Validate() error
+	SetDefaults()
+	SetCanvas(canvas *svg.SVG, width, height int)
+	// copy returns copy of chart that SetDefaults of single draw can change,
+	// classes turns on class names for chart drawn on dashboard with Classes.
+	copy(classes bool) drawer
+	// frame returns canvas and document styles, it is called after SetDefaults.
+	frame() chartFrame
+	// seriesCount returns number of series styled by embedded style sheet.
+	seriesCount() int
+	// draw renders chart body into group opened for it.
+	draw() error
+}
+
+// chartFrame is canvas of chart and styles of its document.
+type chartFrame struct {
+	canvas        *svg.SVG
+	width, height int
+	gstyle        string
+	theme         *Theme
+	classes       bool
+	styleSheetURL string
+}
+
+// drawChart validates chart, resolves its defaults and draws it as complete
+// SVG document.
+func drawChart(chart drawer) error {
+	if err := chart.Validate(); err != nil {
+		return err
+	}
+	c := resolve(chart, false)
+	f := c.frame()
+	start(f.canvas, f.width, f.height, f.gstyle, stylesOf(f.theme, f.classes).BackgroundStyle(),
+		styleSheet(f.theme, f.classes, f.styleSheetURL, c.seriesCount()))
+	err := c.draw()
+	end(f.canvas)
+	return err
+}
+
+// drawChartAt validates chart, resolves its defaults and draws it into its
+// own viewport at x, y of SVG document that is already started. Anything
+// drawn past chart size is clipped. Style sheet is not embedded, document
+// has to include StyleSheet when chart uses Classes or classes is set.
+func drawChartAt(chart drawer, x, y int, classes bool) error {
+	if err := chart.Validate(); err != nil {
+		return err
+	}
+	return drawViewport(resolve(chart, classes), x, y)
+}
+
+// drawViewport draws chart with resolved defaults into its own viewport at x, y.
+func drawViewport(chart drawer, x, y int) error {
+	f := chart.frame()
+	startAt(f.canvas, x, y, f.width, f.height, f.gstyle, stylesOf(f.theme, f.classes).BackgroundStyle())
+	err := chart.draw()
+	endAt(f.canvas)
+	return err
+}
+
+// resolve returns copy of chart with defaults set for single draw, fields of
+// chart are left as caller set them.
+func resolve(chart drawer, classes bool) drawer {
+	c := chart.copy(classes)
+	c.SetDefaults()
+	return c
+}
+
+// legendItem is single legend entry, drawn either as filled box or as line.
+type legendItem struct {
+	label string
+	style string
+	line  bool
+}
+
+// validateCanvas checks fields shared by all charts.
+func validateCanvas(canvas *svg.SVG, width, height int) error {
+	if canvas == nil {
+		return fmt.Errorf("Missing pointer to svg.SVG in field Svg.")
+	}
+	if width < 10 || height < 10 {
+		return fmt.Errorf("Incorrect Width or Height value.")
+	}
+	return nil
+}
+
+// validateValues checks that values named name are finite numbers, NaN and
+// infinite values can not be placed on scale.
+func validateValues(name string, values []float64) error {
+	for i, val := range values {
+		if !finite(val) {
+			return fmt.Errorf("%s item %d is not a number: %v.", name, i, val)
+		}
+	}
+	return nil
+}
+
+// finite reports if all values are neither NaN nor infinite.
+func finite(values ...float64) bool {
+	for _, val := range values {
+		if math.IsNaN(val) || math.IsInf(val, 0) {
+			return false
+		}
+	}
+	return true
+}
+
+// start opens SVG document with style sheet when it is set and top level
+// group with chart style, background is filled when it is set.
+func start(canvas *svg.SVG, width, height int, gstyle, background, sheet string) {
+	canvas.Start(width, height)
+	if sheet != "" {
+		canvas.Style("text/css", sheet)
+	}
+	canvas.Group(gstyle)
+	drawBackground(canvas, width, height, background)
+}
+
+// end closes group and SVG document opened by start.
+func end(canvas *svg.SVG) {
+	canvas.Gend()
+	canvas.End()
+}
+
+// startAt opens nested SVG viewport of width x height at x, y and group with
+// chart style inside of it, it does not start SVG document.
+func startAt(canvas *svg.SVG, x, y, width, height int, gstyle, background string) {
+	fmt.Fprintf(canvas.Writer, "<svg x=\"%d\" y=\"%d\" width=\"%d\" height=\"%d\">\n", x, y, width, height)
+	canvas.Group(gstyle)
+	drawBackground(canvas, width, height, background)
+}
+
+// endAt closes group and viewport opened by startAt.
+func endAt(canvas *svg.SVG) {
+	canvas.Gend()
+	canvas.End()
+}
+
+// drawBackground fills chart area with background style, nothing is drawn for
+// transparent background.
+func drawBackground(canvas *svg.SVG, width, height int, background string) {
+	if background != "" {
+		canvas.Rect(0, 0, width, height, background)
+	}
+}
+
+// drawYLine draws vertical Y line with major markers at pos and minor markers between them.
+func drawYLine(canvas *svg.SVG, x int, scale Scale, pos []float64, style string) {
+	canvas.Line(x-8, int(scale.To), x-8, int(scale.From), style)
+
+	for i, p := range pos {
+		marker := int(p)
+		canvas.Line(x-2, marker, x-14, marker, style)
+		if i > 0 {
+			minor := int((p + pos[i-1]) / 2)
+			canvas.Line(x-5, minor, x-11, minor, style)
+		}
+	}
+}
+
+// drawYLineText draws labels for Y line next to marker positions.
+func drawYLineText(canvas *svg.SVG, x int, pos []float64, labels []string, left bool) {
+	style := "font-size:75%;text-anchor:start;baseline-shift:-33%"
+	if left {
+		style = "font-size:75%;text-anchor:end;baseline-shift:-33%"
+	}
+	for i, label := range labels {
+		canvas.Text(x, int(pos[i]), label, style)
+	}
+}
+
+// drawXLine draws horizontal X line at y with markers and labels below it.
+func drawXLine(canvas *svg.SVG, y, from, to int, pos []float64, labels []string, style string) {
+	canvas.Line(from, y, to, y, style)
+
+	for i, p := range pos {
+		marker := int(p)
+		canvas.Line(marker, y-6, marker, y+6, style)
+		if i > 0 {
+			minor := int((p + pos[i-1]) / 2)
+			canvas.Line(minor, y-3, minor, y+3, style)
+		}
+		canvas.Text(marker, y+18, labels[i], "font-size:75%;text-anchor:middle;")
+	}
+}
+
+// barSlot returns width of slot taken by every bar, count bars fill plot width
+// when spacing is not set.
+func barSlot(plotWidth, count, spacing int) float64 {
+	if spacing > 0 {
+		return float64(spacing)
+	}
+	return float64(plotWidth) / float64(count)
+}
+
+// barWidth returns width of bar that leaves padding part of its slot empty,
+// negative padding set as NoPadding leaves nothing empty.
+func barWidth(slot, padding float64) int {
+	if padding < 0 {
+		padding = 0
+	}
+	width := int(slot * (1 - padding))
+	if width < 1 {
+		return 1
+	}
+	return width
+}
+
+// barOffsets returns left X position of every bar. Bars are centered in slots
+// that follow each other from x, or are centered at their time on scale when
+// times are set.
+func barOffsets(count, x int, slot float64, width int, scale TimeScale, times []time.Time) []int {
+	offsets := make([]int, count)
+	for i := range offsets {
+		if len(times) > 0 {
+			offsets[i] = int(scale.Map(times[i])) - width/2
+		} else {
+			offsets[i] = x + int(float64(i)*slot+(slot-float64(width))/2)
+		}
+	}
+	return offsets
+}
+
+// drawLegend draws legend entries in single row starting at x, y is top of the
+// row, entries are spaced by width of their labels drawn with font size.
+func drawLegend(canvas *svg.SVG, x, y int, size float64, items []legendItem) {
+	canvas.Group(class("vichart-legend"))
+	defer canvas.Gend()
+	for _, item := range items {
+		if item.line {
+			canvas.Line(x, y+15, x+40, y+15, item.style)
+		} else {
+			canvas.Rect(x, y+10, 40, 10, item.style)
+		}
+		canvas.Text(x+50, y+20, item.label, "font-size:75%;")
+		x += legendEntryWidth(item, size)
+	}
+}
@@ -0,0 +1,129 @@
+// ViChart library for Go
+// Author: Tad Vizbaras 
+// License: http://github.com/tadvi/vichart/blob/master/LICENSE 
+//
+package vichart
+
+import (
+	"bytes"
+	"encoding/xml"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/ajstarks/svgo"
+)
+
+// element is single element of drawn SVG document.
+type element struct {
+	name  string
+	attrs map[string]string
+	text  string
+}
+
+// render draws chart that draws into buf and returns parsed elements of the
+// document, buf is emptied first.
+func render(t *testing.T, chart Chart, buf *bytes.Buffer) []element {
+	t.Helper()
+	buf.Reset()
+	if err := chart.Draw(); err != nil {
+		t.Fatal(err)
+	}
+	return parse(t, buf.String())
+}
+
+// parse returns elements of SVG document in document order.
+func parse(t *testing.T, doc string) []element {
+	t.Helper()
+	var elements []element
+	d := xml.NewDecoder(strings.NewReader(doc))
+	for {
+		tok, err := d.Token()
+		if err == io.EOF {
+			return elements
+		}
+		if err != nil {
+			t.Fatalf("document is not valid XML: %v\n%s", err, doc)
+		}
+		switch tok := tok.(type) {
+		case xml.StartElement:
+			e := element{name: tok.Name.Local, attrs: map[string]string{}}
+			for _, a := range tok.Attr {
+				e.attrs[a.Name.Local] = a.Value
+			}
+			elements = append(elements, e)
+		case xml.CharData:
+			if len(elements) > 0 {
+				elements[len(elements)-1].text += strings.TrimSpace(string(tok))
+			}
+		}
+	}
+}
+
+// named returns elements with name.
+func named(elements []element, name string) []element {
+	var found []element
+	for _, e := range elements {
+		if e.name == name {
+			found = append(found, e)
+		}
+	}
+	return found
+}
+
+// withStyle returns elements whose style contains style.
+func withStyle(elements []element, style string) []element {
+	var found []element
+	for _, e := range elements {
+		if strings.Contains(e.attrs["style"], style) {
+			found = append(found, e)
+		}
+	}
+	return found
+}
+
+func TestDrawFollowsTheme(t *testing.T) {
+	var buf bytes.Buffer
+	chart := VBarChart{Svg: svg.New(&buf), Width: 400, Height: 300, BarValues: []float64{1, 2}}
+	doc := render(t, &chart, &buf)
+	if bars := withStyle(named(doc, "rect"), LightTheme.FillStyle(1)); len(bars) != 2 {
+		t.Errorf("light theme draws %d bars with %q, want 2", len(bars), LightTheme.FillStyle(1))
+	}
+	chart.Theme = &DarkTheme
+	doc = render(t, &chart, &buf)
+	if bars := withStyle(named(doc, "rect"), DarkTheme.FillStyle(1)); len(bars) != 2 {
+		t.Errorf("dark theme set after draw draws %d bars with %q, want 2", len(bars), DarkTheme.FillStyle(1))
+	}
+}
+
+func TestDrawKeepsFields(t *testing.T) {
+	var buf bytes.Buffer
+	canvas := svg.New(&buf)
+	vbar := &VBarChart{Svg: canvas, Width: 400, Height: 300, BarValues: []float64{1, -2}}
+	multi := &VBMultiChart{Svg: canvas, Width: 400, Height: 300, BarValues: []VBMultiChartItem{{1, 2}}}
+	line := &LineChart{Svg: canvas, Width: 400, Height: 300, Series: []LineSeries{{Values: []float64{1, 2}}}}
+	hbar := &HBarChart{Svg: canvas, Width: 400, Height: 300, BarValues: []float64{1, 2}, LabelsY: []string{"a", "b"}}
+	pie := &PieChart{Svg: canvas, Width: 400, Height: 300, PieValues: []float64{1, 2}, Labels: []string{"a", "b"}}
+	tests := []struct {
+		name  string
+		chart Chart
+		set   func() []string // fields that draw must leave empty
+	}{
+		{"VBarChart", vbar, func() []string { return []string{vbar.Gstyle, vbar.BarStyle, vbar.LineXYStyle} }},
+		{"VBMultiChart", multi, func() []string { return []string{multi.Gstyle, multi.LineStyle} }},
+		{"LineChart", line, func() []string { return []string{line.Gstyle, line.Series[0].Style, line.Series[0].MarkerStyle} }},
+		{"HBarChart", hbar, func() []string { return []string{hbar.Gstyle, hbar.BarStyle, hbar.BarCapStyle, hbar.LineXStyle} }},
+		{"PieChart", pie, func() []string { return append([]string{pie.Gstyle, pie.PieStyle}, pie.FillStyles...) }},
+	}
+	for _, tt := range tests {
+		render(t, tt.chart, &buf)
+		for i, field := range tt.set() {
+			if field != "" {
+				t.Errorf("%s: draw set field %d to %q", tt.name, i, field)
+			}
+		}
+	}
+	if len(multi.Series) != 0 {
+		t.Errorf("VBMultiChart: draw added %d series", len(multi.Series))
+	}
+}
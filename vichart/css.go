@@ -0,0 +1,167 @@
+// ViChart library for Go
+// Author: Tad Vizbaras 
+// License: http://github.com/tadvi/vichart/blob/master/LICENSE 
+//
+package vichart
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// chartStyles is source of default chart styles, Theme gives inline styles
+// and classStyles gives class names styled by StyleSheet.
+type chartStyles interface {
+	Gstyle() string
+	BackgroundStyle() string
+	AxisStyle() string
+	GridStyle() string
+	MinorGridStyle() string
+	FillStyle(i int) string
+	BarCapStyle() string
+	LineStyle(i int) string
+	MarkerStyle(i int) string
+}
+
+// stylesOf returns class names when classes are used, theme styles otherwise.
+func stylesOf(theme *Theme, classes bool) chartStyles {
+	if classes {
+		return classStyles{}
+	}
+	return themeOf(theme)
+}
+
+// classStyles returns class attributes instead of inline styles, svgo writes
+// style strings with "=" as attributes.
+type classStyles struct{}
+
+func (classStyles) Gstyle() string           { return class("vichart") }
+func (classStyles) BackgroundStyle() string  { return class("vichart-background") }
+func (classStyles) AxisStyle() string        { return class("vichart-axis") }
+func (classStyles) GridStyle() string        { return class("vichart-grid") }
+func (classStyles) MinorGridStyle() string   { return class("vichart-grid", "vichart-grid-minor") }
+func (classStyles) FillStyle(i int) string   { return class("vichart-bar", seriesClass(i)) }
+func (classStyles) BarCapStyle() string      { return class("vichart-bar-cap") }
+func (classStyles) LineStyle(i int) string   { return class("vichart-line", seriesClass(i)) }
+func (classStyles) MarkerStyle(i int) string { return class("vichart-marker") }
+
+// class returns class attribute with names.
+func class(names ...string) string {
+	return fmt.Sprintf(`class="%s"`, strings.Join(names, " "))
+}
+
+// seriesClass returns class name of series i.
+func seriesClass(i int) string {
+	return fmt.Sprintf("vichart-series-%d", i)
+}
+
+var attrRe = regexp.MustCompile(`([\w-]+)="([^"]*)"`)
+
+// joinStyles joins inline styles and class attributes into single string for
+// svgo. Inline styles are returned as they are when there are no attributes,
+// otherwise class names are merged into one class attribute and inline
+// styles into one style attribute.
+func joinStyles(list ...string) string {
+	var classes, inline, attrs []string
+	for _, s := range list {
+		if !strings.Contains(s, `="`) {
+			if s != "" {
+				inline = append(inline, s)
+			}
+			continue
+		}
+		for _, m := range attrRe.FindAllStringSubmatch(s, -1) {
+			switch m[1] {
+			case "class":
+				classes = append(classes, m[2])
+			case "style":
+				inline = append(inline, m[2])
+			default:
+				attrs = append(attrs, m[0])
+			}
+		}
+	}
+	if len(classes) == 0 && len(attrs) == 0 {
+		return joinInline(inline)
+	}
+	if len(classes) > 0 {
+		attrs = append(attrs, class(classes...))
+	}
+	if len(inline) > 0 {
+		attrs = append(attrs, fmt.Sprintf(`style="%s"`, joinInline(inline)))
+	}
+	return strings.Join(attrs, " ")
+}
+
+// joinInline joins inline styles with semicolons whether or not they end with
+// one, single style is returned as it is.
+func joinInline(inline []string) string {
+	if len(inline) == 1 {
+		return inline[0]
+	}
+	var parts []string
+	for _, s := range inline {
+		if s = strings.TrimRight(strings.TrimSpace(s), "; "); s != "" {
+			parts = append(parts, s+";")
+		}
+	}
+	return strings.Join(parts, "")
+}
+
+// StyleSheet returns CSS for charts drawn with Classes set, colors come from
+// theme and there is rule for at least n series. Web page can override any of
+// the rules with its own CSS.
+func StyleSheet(theme *Theme, n int) string {
+	t := themeOf(theme)
+	if n < len(t.colors()) {
+		n = len(t.colors())
+	}
+	marker := t.Background
+	if marker == "" {
+		marker = "white"
+	}
+	var b strings.Builder
+	fmt.Fprintf(&b, ".vichart { font-family: %s; font-size: %dpx; }\n", t.fontFamily(), t.fontSize())
+	if t.TextColor != "" {
+		fmt.Fprintf(&b, ".vichart text { fill: %s; }\n", t.TextColor)
+	}
+	if t.Background != "" {
+		fmt.Fprintf(&b, ".vichart-background { fill: %s; }\n", t.Background)
+	} else {
+		b.WriteString(".vichart-background { fill: none; }\n")
+	}
+	fmt.Fprintf(&b, ".vichart-axis { stroke: %s; stroke-width: 2px; }\n", t.AxisColor)
+	fmt.Fprintf(&b, ".vichart-grid { stroke: %s; stroke-width: 1px; }\n", t.GridColor)
+	b.WriteString(".vichart-grid-minor { stroke-dasharray: 2,3; }\n")
+	fmt.Fprintf(&b, ".vichart-bar, .vichart-slice { stroke: %s; }\n", t.Stroke)
+	fmt.Fprintf(&b, ".vichart-bar-cap { fill: %s; fill-opacity: 0.3; }\n", t.Stroke)
+	b.WriteString(".vichart-line { fill: none; stroke-width: 2px; }\n")
+	fmt.Fprintf(&b, ".vichart-line.vichart-marker { fill: %s; }\n", marker)
+	fmt.Fprintf(&b, ".vichart-slice.vichart-other { fill: %s; }\n", t.AxisColor)
+	fmt.Fprintf(&b, ".vichart-leader { fill: none; stroke: %s; stroke-width: 1px; }\n", t.AxisColor)
+	b.WriteString(".vichart-highlight { stroke: black; stroke-width: 2px; }\n")
+	for i := 0; i < n; i++ {
+		fmt.Fprintf(&b, ".vichart-bar.%[1]s, .vichart-slice.%[1]s { fill: %[2]s; }\n", seriesClass(i), t.Color(i))
+		fmt.Fprintf(&b, ".vichart-line.%s { stroke: %s; }\n", seriesClass(i), t.Color(i))
+	}
+	return b.String()
+}
+
+// AdaptiveStyleSheet returns StyleSheet of light theme that switches to dark
+// theme when reader prefers dark color scheme.
+func AdaptiveStyleSheet(light, dark *Theme, n int) string {
+	return StyleSheet(light, n) + "@media (prefers-color-scheme: dark) {\n" + StyleSheet(dark, n) + "}\n"
+}
+
+// styleSheet returns CSS embedded into chart document drawn with classes,
+// either import of url or StyleSheet generated from theme.
+func styleSheet(theme *Theme, classes bool, url string, n int) string {
+	if !classes {
+		return ""
+	}
+	if url != "" {
+		return fmt.Sprintf("@import url(%q);", url)
+	}
+	return StyleSheet(theme, n)
+}
@@ -0,0 +1,28 @@
+// ViChart library for Go
+// Author: Tad Vizbaras 
+// License: http://github.com/tadvi/vichart/blob/master/LICENSE 
+//
+package vichart
+
+import "testing"
+
+func TestJoinStyles(t *testing.T) {
+	tests := []struct {
+		list []string
+		want string
+	}{
+		{[]string{"fill:red"}, "fill:red"},
+		{[]string{"fill:red", "stroke:blue;stroke-width:2"}, "fill:red;stroke:blue;stroke-width:2;"},
+		{[]string{"fill:red;", " stroke:blue; ", ""}, "fill:red;stroke:blue;"},
+		{[]string{"", "fill:red"}, "fill:red"},
+		{[]string{class("vichart-bar"), "fill:red", class("vichart-highlight")},
+			`class="vichart-bar vichart-highlight" style="fill:red"`},
+		{[]string{`style="fill:red"`, "stroke:blue"}, "fill:red;stroke:blue;"},
+		{[]string{class("vichart-bar")}, `class="vichart-bar"`},
+	}
+	for _, tt := range tests {
+		if got := joinStyles(tt.list...); got != tt.want {
+			t.Errorf("joinStyles(%q) = %q, want %q", tt.list, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,290 @@
+// ViChart library for Go
+// Author: Tad Vizbaras 
+// License: http://github.com/tadvi/vichart/blob/master/LICENSE 
+//
+package vichart
+
+import (
+	"fmt"
+	"github.com/ajstarks/svgo"
+)
+
+const (
+	DashboardTitleStyle     = "font-size:150%;text-anchor:middle;"
+	DashboardCellTitleStyle = "text-anchor:middle;"
+
+	DashboardGap             = 10
+	DashboardTitleHeight     = 30
+	DashboardCellTitleHeight = 20
+)
+
+// Deprecated: Dashboard takes its font from Theme.
+const (
+	DashboardGstyle = "font-family:Calibri; font-size:14"
+)
+
+// Dashboard lays out rows of charts into single SVG document. Row heights and
+// cell widths are relative weights of the available space.
+type Dashboard struct {
+	Svg           *svg.SVG
+	Width, Height int
+	Rows          []DashboardRow
+
+	// optional fields below
+	Title           string
+	Gap             int // space between rows and cells
+	TitleHeight     int // space reserved for dashboard title
+	CellTitleHeight int // space reserved for row and cell titles
+
+	// styles, Gstyle is taken from Theme if not set. Theme is not passed to
+	// charts, set DefaultTheme to restyle whole dashboard. Classes is passed
+	// to charts and Draw embeds single StyleSheet for all of them, DrawAt
+	// expects the document to include it.
+	Classes        bool   // emit class names styled by StyleSheet instead of inline styles
+	StyleSheetURL  string // with Classes, Draw imports this CSS instead of embedding StyleSheet of Theme
+	Theme          *Theme // DefaultTheme is used if not set
+	Gstyle         string
+	TitleStyle     string
+	CellTitleStyle string
+}
+
+// DashboardRow is single row of dashboard charts.
+type DashboardRow struct {
+	Cells  []DashboardCell
+	Weight float64 // relative height of the row, 1 if not set
+	Title  string  // optional title shared by all charts in the row
+}
+
+// DashboardCell is single chart in dashboard row.
+type DashboardCell struct {
+	Chart  Chart
+	Weight float64 // relative width of the cell within row, 1 if not set
+	Title  string  // optional title drawn above the chart
+}
+
+// dashboardBox is position and size of single chart on dashboard.
+type dashboardBox struct {
+	chart         Chart
+	x, y          int
+	width, height int
+}
+
+// dashboardTitle is row or cell title centered at x.
+type dashboardTitle struct {
+	text string
+	x, y int
+}
+
+// Validate checks that all required dashboard fields are set, copies of
+// charts fitted into their cells are validated too, so nothing is drawn when
+// any of them is not valid. Charts on dashboard are not changed.
+func (chart *Dashboard) Validate() error {
+	if err := validateCanvas(chart.Svg, chart.Width, chart.Height); err != nil {
+		return err
+	}
+	if len(chart.Rows) == 0 {
+		return fmt.Errorf("Missing Rows for the dashboard.")
+	}
+	for i, row := range chart.Rows {
+		if len(row.Cells) == 0 {
+			return fmt.Errorf("Missing Cells in dashboard row %d.", i)
+		}
+		if row.Weight < 0 {
+			return fmt.Errorf("Negative Weight in dashboard row %d.", i)
+		}
+		for j, cell := range row.Cells {
+			if cell.Chart == nil {
+				return fmt.Errorf("Missing Chart in dashboard row %d cell %d.", i, j)
+			}
+			if cell.Weight < 0 {
+				return fmt.Errorf("Negative Weight in dashboard row %d cell %d.", i, j)
+			}
+		}
+	}
+	// layout of copy with defaults does not change fields of dashboard
+	sized := *chart
+	sized.SetDefaults()
+	boxes, _ := sized.layout()
+	for _, box := range boxes {
+		c, ok := box.chart.(drawer)
+		if !ok {
+			continue // validated by its DrawAt
+		}
+		if err := chart.fitted(c, box).Validate(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// SetDefaults sets sensible constants for optional fields that are not set.
+func (chart *Dashboard) SetDefaults() {
+	if chart.Gstyle == "" {
+		chart.Gstyle = stylesOf(chart.Theme, chart.Classes).Gstyle()
+	}
+	if chart.TitleStyle == "" {
+		chart.TitleStyle = DashboardTitleStyle
+	}
+	if chart.CellTitleStyle == "" {
+		chart.CellTitleStyle = DashboardCellTitleStyle
+	}
+	if chart.Gap == 0 {
+		chart.Gap = DashboardGap
+	}
+	if chart.TitleHeight == 0 {
+		chart.TitleHeight = DashboardTitleHeight
+	}
+	if chart.CellTitleHeight == 0 {
+		chart.CellTitleHeight = DashboardCellTitleHeight
+	}
+}
+
+// Draw produces dashboard on screen, main entry point.
+func (chart *Dashboard) Draw() error {
+	return drawChart(chart)
+}
+
+// DrawAt draws dashboard into its own viewport at x, y of existing SVG
+// document without starting or ending it.
+func (chart *Dashboard) DrawAt(x, y int) error {
+	return drawChartAt(chart, x, y, false)
+}
+
+// SetCanvas points dashboard to canvas and sets its size, so dashboards can be nested.
+func (chart *Dashboard) SetCanvas(canvas *svg.SVG, width, height int) {
+	chart.Svg = canvas
+	chart.Width, chart.Height = width, height
+}
+
+// frame returns canvas of the dashboard and styles of its document.
+func (chart *Dashboard) frame() chartFrame {
+	return chartFrame{chart.Svg, chart.Width, chart.Height, chart.Gstyle, chart.Theme, chart.Classes,
+		chart.StyleSheetURL}
+}
+
+// seriesCount returns the largest number of series of charts on dashboard,
+// so single style sheet styles all of them.
+func (chart *Dashboard) seriesCount() int {
+	n := 0
+	for _, row := range chart.Rows {
+		for _, cell := range row.Cells {
+			if c, ok := cell.Chart.(drawer); ok && c.seriesCount() > n {
+				n = c.seriesCount()
+			}
+		}
+	}
+	return n
+}
+
+// copy returns copy of chart for single draw, classes turns on class names.
+func (chart *Dashboard) copy(classes bool) drawer {
+	c := *chart
+	c.Classes = c.Classes || classes
+	return &c
+}
+
+// draw renders dashboard title and all charts.
+func (chart *Dashboard) draw() error {
+	canvas := chart.Svg
+	if chart.Title != "" {
+		canvas.Text(chart.Width/2, chart.TitleHeight*2/3, chart.Title, chart.TitleStyle)
+	}
+	boxes, titles := chart.layout()
+	for _, title := range titles {
+		canvas.Text(title.x, title.y, title.text, chart.CellTitleStyle)
+	}
+	for _, box := range boxes {
+		c, ok := box.chart.(drawer)
+		if !ok {
+			// chart of other type is pointed to dashboard canvas and draws itself
+			box.chart.SetCanvas(chart.Svg, box.width, box.height)
+			if err := box.chart.DrawAt(box.x, box.y); err != nil {
+				return err
+			}
+			continue
+		}
+		fitted := chart.fitted(c, box)
+		fitted.SetDefaults()
+		if err := drawViewport(fitted, box.x, box.y); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// fitted returns copy of chart on dashboard canvas sized to its box, copy
+// uses class names when dashboard does.
+func (chart *Dashboard) fitted(c drawer, box dashboardBox) drawer {
+	fitted := c.copy(chart.Classes)
+	fitted.SetCanvas(chart.Svg, box.width, box.height)
+	return fitted
+}
+
+// layout returns position and size of every chart and positions of row and cell
+// titles. Space is shared between rows and cells by weight after gaps and
+// titles are taken out.
+func (chart *Dashboard) layout() ([]dashboardBox, []dashboardTitle) {
+	var boxes []dashboardBox
+	var titles []dashboardTitle
+
+	top := 0
+	if chart.Title != "" {
+		top = chart.TitleHeight
+	}
+	rowWeights := make([]float64, len(chart.Rows))
+	for i, row := range chart.Rows {
+		rowWeights[i] = row.Weight
+	}
+	heights := share(chart.Height-top, chart.Gap, rowWeights)
+	textOffset := chart.CellTitleHeight * 2 / 3
+
+	y := top
+	for i, row := range chart.Rows {
+		rowY, rowHeight := y, heights[i]
+		if row.Title != "" {
+			titles = append(titles, dashboardTitle{row.Title, chart.Width / 2, y + textOffset})
+			rowY += chart.CellTitleHeight
+			rowHeight -= chart.CellTitleHeight
+		}
+		cellWeights := make([]float64, len(row.Cells))
+		for j, cell := range row.Cells {
+			cellWeights[j] = cell.Weight
+		}
+		widths := share(chart.Width, chart.Gap, cellWeights)
+
+		x := 0
+		for j, cell := range row.Cells {
+			cy, h := rowY, rowHeight
+			if cell.Title != "" {
+				titles = append(titles, dashboardTitle{cell.Title, x + widths[j]/2, cy + textOffset})
+				cy += chart.CellTitleHeight
+				h -= chart.CellTitleHeight
+			}
+			boxes = append(boxes, dashboardBox{cell.Chart, x, cy, widths[j], h})
+			x += widths[j] + chart.Gap
+		}
+		y += heights[i] + chart.Gap
+	}
+	return boxes, titles
+}
+
+// share splits total size minus gaps between items by their weights, items
+// without weight get weight of 1.
+func share(total, gap int, weights []float64) []int {
+	sum := 0.0
+	for _, w := range weights {
+		if w == 0 {
+			w = 1
+		}
+		sum += w
+	}
+	space := float64(total - gap*(len(weights)-1))
+	sizes := make([]int, len(weights))
+	for i, w := range weights {
+		if w == 0 {
+			w = 1
+		}
+		sizes[i] = int(space * w / sum)
+	}
+	return sizes
+}
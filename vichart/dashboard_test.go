@@ -0,0 +1,42 @@
+// ViChart library for Go
+// Author: Tad Vizbaras 
+// License: http://github.com/tadvi/vichart/blob/master/LICENSE 
+//
+package vichart
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/ajstarks/svgo"
+)
+
+func TestDashboardKeepsCharts(t *testing.T) {
+	var buf, own bytes.Buffer
+	canvas := svg.New(&own)
+	chart := &VBarChart{Svg: canvas, Width: 200, Height: 100, BarValues: []float64{1, 2}}
+	render(t, chart, &own) // drawn once before it is put on dashboard
+	dashboard := Dashboard{Svg: svg.New(&buf), Width: 800, Height: 600, Classes: true,
+		Rows: []DashboardRow{{Cells: []DashboardCell{{Chart: chart}}}}}
+	if err := dashboard.Validate(); err != nil {
+		t.Fatal(err)
+	}
+	doc := render(t, &dashboard, &buf)
+	if chart.Svg != canvas || chart.Width != 200 || chart.Height != 100 || chart.Classes {
+		t.Errorf("dashboard changed chart to canvas %p, size %dx%d, classes %v",
+			chart.Svg, chart.Width, chart.Height, chart.Classes)
+	}
+	bars := 0
+	for _, e := range named(doc, "rect") {
+		if strings.Contains(e.attrs["class"], "vichart-bar") {
+			bars++
+		}
+	}
+	if bars != 2 {
+		t.Errorf("dashboard with classes draws %d bars with class names, want 2", bars)
+	}
+	if bars := withStyle(named(render(t, chart, &own), "rect"), DefaultTheme.FillStyle(1)); len(bars) != 2 {
+		t.Errorf("chart drawn after dashboard draws %d bars with inline style, want 2", len(bars))
+	}
+}
@@ -0,0 +1,174 @@
+// ViChart library for Go
+// Author: Tad Vizbaras 
+// License: http://github.com/tadvi/vichart/blob/master/LICENSE 
+//
+package vichart
+
+import (
+	"math"
+	"strconv"
+	"strings"
+)
+
+// Formatter formats numbers for axis labels and value labels.
+type Formatter func(value float64) string
+
+// Locale holds separators and currency used by formatters.
+type Locale struct {
+	Decimal        string // decimal separator, "." if not set
+	Group          string // thousands separator
+	CurrencySymbol string // currency symbol
+	CurrencyAfter  bool   // currency symbol goes after the number, separated by space
+}
+
+// built-in locales
+var (
+	EnglishLocale = Locale{Decimal: ".", Group: ",", CurrencySymbol: "$"}
+	GermanLocale  = Locale{Decimal: ",", Group: ".", CurrencySymbol: "€", CurrencyAfter: true}
+	FrenchLocale  = Locale{Decimal: ",", Group: "\u202f", CurrencySymbol: "€", CurrencyAfter: true}
+	SwissLocale   = Locale{Decimal: ".", Group: "'", CurrencySymbol: "CHF", CurrencyAfter: true}
+)
+
+// DefaultLocale is used by formatters that are not created from Locale and by
+// labels that do not have formatter set.
+var DefaultLocale = EnglishLocale
+
+// siPrefixes are SI suffixes from the largest one, each is thousand times the next one.
+var siPrefixes = []struct {
+	suffix string
+	factor float64
+}{{"T", 1e12}, {"G", 1e9}, {"M", 1e6}, {"k", 1e3}}
+
+// Fixed returns formatter with decimals digits after decimal separator,
+// negative decimals give the shortest form that represents value exactly.
+func (l Locale) Fixed(decimals int) Formatter {
+	return func(value float64) string {
+		return l.number(value, decimals, false)
+	}
+}
+
+// Thousands returns formatter like Fixed with groups of thousands separated.
+func (l Locale) Thousands(decimals int) Formatter {
+	return func(value float64) string {
+		return l.number(value, decimals, true)
+	}
+}
+
+// SI returns formatter that shortens large numbers with SI suffix, 1200 as 1.2k
+// and 3400000 as 3.4M. Trailing zeros of decimals are dropped.
+func (l Locale) SI(decimals int) Formatter {
+	return func(value float64) string {
+		for _, prefix := range siPrefixes {
+			scaled := value / prefix.factor
+			if math.Abs(round(scaled, decimals)) >= 1 {
+				return trimZeros(l.number(scaled, decimals, false), l.decimal()) + prefix.suffix
+			}
+		}
+		return trimZeros(l.number(value, decimals, false), l.decimal())
+	}
+}
+
+// Percent returns formatter of fractions, 0.25 is formatted as 25%.
+func (l Locale) Percent(decimals int) Formatter {
+	return func(value float64) string {
+		return l.number(value*100, decimals, false) + "%"
+	}
+}
+
+// Currency returns formatter of amounts with currency symbol and separated
+// groups of thousands.
+func (l Locale) Currency(decimals int) Formatter {
+	return func(value float64) string {
+		number := l.number(math.Abs(value), decimals, true)
+		if l.CurrencyAfter {
+			number += " " + l.CurrencySymbol
+		} else {
+			number = l.CurrencySymbol + number
+		}
+		if value < 0 && round(value, decimals) != 0 {
+			return "-" + number
+		}
+		return number
+	}
+}
+
+// Fixed returns Fixed formatter of DefaultLocale, locale is taken when value
+// is formatted.
+func Fixed(decimals int) Formatter {
+	return func(value float64) string { return DefaultLocale.Fixed(decimals)(value) }
+}
+
+// Thousands returns Thousands formatter of DefaultLocale.
+func Thousands(decimals int) Formatter {
+	return func(value float64) string { return DefaultLocale.Thousands(decimals)(value) }
+}
+
+// SI returns SI formatter of DefaultLocale.
+func SI(decimals int) Formatter {
+	return func(value float64) string { return DefaultLocale.SI(decimals)(value) }
+}
+
+// Percent returns Percent formatter of DefaultLocale.
+func Percent(decimals int) Formatter {
+	return func(value float64) string { return DefaultLocale.Percent(decimals)(value) }
+}
+
+// Currency returns Currency formatter of DefaultLocale.
+func Currency(decimals int) Formatter {
+	return func(value float64) string { return DefaultLocale.Currency(decimals)(value) }
+}
+
+// formatValue formats value in shortest decimal form with DefaultLocale, it is
+// used for values when chart does not have formatter set.
+func formatValue(value float64) string {
+	return DefaultLocale.number(value, -1, false)
+}
+
+// number formats value with decimals digits, negative decimals give the shortest
+// form, and optionally separates groups of thousands.
+func (l Locale) number(value float64, decimals int, group bool) string {
+	s := strconv.FormatFloat(math.Abs(value), 'f', decimals, 64)
+	whole, fraction := s, ""
+	if i := strings.IndexByte(s, '.'); i >= 0 {
+		whole, fraction = s[:i], s[i+1:]
+	}
+	if group {
+		for i := len(whole) - 3; i > 0; i -= 3 {
+			whole = whole[:i] + l.Group + whole[i:]
+		}
+	}
+	if fraction != "" {
+		whole += l.decimal() + fraction
+	}
+	// value rounded to zero is written without sign
+	if value < 0 && strings.Trim(s, "0.") != "" {
+		return "-" + whole
+	}
+	return whole
+}
+
+// decimal returns decimal separator of the locale.
+func (l Locale) decimal() string {
+	if l.Decimal == "" {
+		return "."
+	}
+	return l.Decimal
+}
+
+// round rounds value to decimals digits, negative decimals leave value as is.
+func round(value float64, decimals int) float64 {
+	if decimals < 0 {
+		return value
+	}
+	p := math.Pow(10, float64(decimals))
+	return math.Round(value*p) / p
+}
+
+// trimZeros drops trailing zeros of fraction and decimal separator left alone,
+// number without decimal separator is returned as is.
+func trimZeros(s, decimal string) string {
+	if decimal == "" || !strings.Contains(s, decimal) {
+		return s
+	}
+	return strings.TrimSuffix(strings.TrimRight(s, "0"), decimal)
+}
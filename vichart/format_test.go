@@ -0,0 +1,102 @@
+// ViChart library for Go
+// Author: Tad Vizbaras 
+// License: http://github.com/tadvi/vichart/blob/master/LICENSE 
+//
+package vichart
+
+import "testing"
+
+func TestLocaleNumber(t *testing.T) {
+	tests := []struct {
+		locale   Locale
+		value    float64
+		decimals int
+		group    bool
+		want     string
+	}{
+		{EnglishLocale, 1234567.891, 2, true, "1,234,567.89"},
+		{EnglishLocale, 1234567.891, 2, false, "1234567.89"},
+		{GermanLocale, 1234567.891, 2, true, "1.234.567,89"},
+		{FrenchLocale, 1234.5, 1, true, "1\u202f234,5"},
+		{SwissLocale, 1234567, 0, true, "1'234'567"},
+		{EnglishLocale, 123, 0, true, "123"},
+		{EnglishLocale, -1234.5, 1, true, "-1,234.5"},
+		{EnglishLocale, -0.004, 2, false, "0.00"},
+		{EnglishLocale, 1.25, -1, false, "1.25"},
+		{Locale{}, 1.5, 1, false, "1.5"},
+	}
+	for _, tt := range tests {
+		if got := tt.locale.number(tt.value, tt.decimals, tt.group); got != tt.want {
+			t.Errorf("%+v.number(%v, %d, %v) = %q, want %q", tt.locale, tt.value, tt.decimals, tt.group, got, tt.want)
+		}
+	}
+}
+
+func TestLocaleSI(t *testing.T) {
+	tests := []struct {
+		locale   Locale
+		decimals int
+		value    float64
+		want     string
+	}{
+		{EnglishLocale, 1, 1200, "1.2k"},
+		{EnglishLocale, 1, 3400000, "3.4M"},
+		{EnglishLocale, 1, 5e9, "5G"},
+		{EnglishLocale, 1, 2.5e12, "2.5T"},
+		{EnglishLocale, 1, 1000, "1k"},
+		{EnglishLocale, 1, 940, "940"},
+		{EnglishLocale, 1, 999, "1k"}, // 0.999k rounds to 1k
+		{EnglishLocale, 1, 999999, "1M"},
+		{EnglishLocale, 1, -2500, "-2.5k"},
+		{EnglishLocale, 1, 0, "0"},
+		{EnglishLocale, 2, 0.5, "0.5"},
+		{GermanLocale, 2, 1234, "1,23k"},
+		{Locale{}, 1, 100, "100"},
+		{Locale{}, 1, 1500, "1.5k"},
+	}
+	for _, tt := range tests {
+		if got := tt.locale.SI(tt.decimals)(tt.value); got != tt.want {
+			t.Errorf("%+v.SI(%d)(%v) = %q, want %q", tt.locale, tt.decimals, tt.value, got, tt.want)
+		}
+	}
+}
+
+func TestLocaleCurrency(t *testing.T) {
+	tests := []struct {
+		locale   Locale
+		decimals int
+		value    float64
+		want     string
+	}{
+		{EnglishLocale, 2, 1234.5, "$1,234.50"},
+		{EnglishLocale, 2, -1234.5, "-$1,234.50"},
+		{EnglishLocale, 2, -0.001, "$0.00"},
+		{EnglishLocale, 0, 0, "$0"},
+		{GermanLocale, 0, 1234, "1.234 €"},
+		{GermanLocale, 2, -5, "-5,00 €"},
+		{SwissLocale, 2, 1234567.891, "1'234'567.89 CHF"},
+	}
+	for _, tt := range tests {
+		if got := tt.locale.Currency(tt.decimals)(tt.value); got != tt.want {
+			t.Errorf("%+v.Currency(%d)(%v) = %q, want %q", tt.locale, tt.decimals, tt.value, got, tt.want)
+		}
+	}
+}
+
+func TestTrimZeros(t *testing.T) {
+	tests := []struct {
+		s, decimal string
+		want       string
+	}{
+		{"1.500", ".", "1.5"},
+		{"2.000", ".", "2"},
+		{"100", ".", "100"},
+		{"1,50", ",", "1,5"},
+		{"100", "", "100"},
+	}
+	for _, tt := range tests {
+		if got := trimZeros(tt.s, tt.decimal); got != tt.want {
+			t.Errorf("trimZeros(%q, %q) = %q, want %q", tt.s, tt.decimal, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,53 @@
+// ViChart library for Go
+// Author: Tad Vizbaras 
+// License: http://github.com/tadvi/vichart/blob/master/LICENSE 
+//
+package vichart
+
+import (
+	"github.com/ajstarks/svgo"
+)
+
+// Grid selects gridlines drawn across plot behind the data, values can be
+// combined, for example GridY | GridMinorY.
+type Grid int
+
+const (
+	GridY      Grid = 1 << iota // horizontal lines at Y line markers
+	GridX                       // vertical lines at X line markers
+	GridMinorY                  // horizontal lines halfway between Y line markers
+	GridMinorX                  // vertical lines halfway between X line markers
+
+	GridNone Grid = 0
+	GridBoth      = GridX | GridY
+)
+
+// drawGrid draws gridlines across plot between left, top, right and bottom
+// edges. Vertical lines go at xpos and horizontal at ypos, which are positions
+// of axis markers. Minor lines go halfway between markers and are drawn first,
+// so major lines stay on top of them.
+func drawGrid(canvas *svg.SVG, grid Grid, xpos, ypos []float64, left, top, right, bottom int, style, minorStyle string) {
+	if grid == GridNone {
+		return
+	}
+	canvas.Group(class("vichart-gridlines"))
+	defer canvas.Gend()
+	for i := 1; i < len(xpos) && grid&GridMinorX != 0; i++ {
+		minor := int((xpos[i] + xpos[i-1]) / 2)
+		canvas.Line(minor, top, minor, bottom, minorStyle)
+	}
+	for i := 1; i < len(ypos) && grid&GridMinorY != 0; i++ {
+		minor := int((ypos[i] + ypos[i-1]) / 2)
+		canvas.Line(left, minor, right, minor, minorStyle)
+	}
+	for _, p := range xpos {
+		if grid&GridX != 0 {
+			canvas.Line(int(p), top, int(p), bottom, style)
+		}
+	}
+	for _, p := range ypos {
+		if grid&GridY != 0 {
+			canvas.Line(left, int(p), right, int(p), style)
+		}
+	}
+}
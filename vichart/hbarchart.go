@@ -7,122 +7,266 @@ package vichart
 import (
 	"fmt"
 	"github.com/ajstarks/svgo"
+	"math"
 )
 
 const (
-	HBarLineXStyle  = "stroke:lightgray;stroke-width:2px;"
-	HBarGstyle      = "font-family:Calibri; font-size:14"
+	HBarSpacing = 18
+)
+
+// Deprecated: HBarChart takes its default styles from Theme.
+const (
+	HBarLineXStyle = "stroke:lightgray;stroke-width:2px;"
+	HBarGstyle     = "font-family:Calibri; font-size:14"
+)
+
+// Deprecated: gutters that are not set are sized from labels and values.
+const (
 	HBarGutterLeft  = 100
 	HBarGutterRight = 20
-	HBarSpacing     = 18
 )
 
 type HBarChart struct {
 	Svg           *svg.SVG
 	Width, Height int
-	BarValues     []int // chart values
-	MaxValue      int   // chart max value, used for scaling all the display values
-	LabelsY       []string
+	BarValues     []float64 // chart values, negative values grow left from zero
+	MaxValue      float64   // chart max value, used for scaling all the display values, computed from BarValues if not set
+	MinValue      float64   // chart min value, computed from BarValues if not set
+	LabelsY       []string  // bar labels, labels wider than left gutter are cut with ellipsis
 
 	// optional fields below
 	BarSpacing  int
 	LabelsX     []string
+	FormatX     Formatter // formats generated X line labels, with precision of tick step if not set
+	ScaleX      ScaleType // scale of bars and X line, linear if not set
+	Grid        Grid      // gridlines drawn behind bars, horizontal lines go between bars, none if not set
 	GutterLeft  int
 	GutterRight int // right gutter for the chart, used to fit last bottom label
 
-	// styles
-	Gstyle     string
-	LineXStyle string
+	// value labels, written outside of bars if not set
+	ValueLabels ValueLabel
+	ValueFormat Formatter // formats value labels, FormatX or shortest decimal form if not set
+
+	// titles, plot area shrinks to make room for them
+	Title      string
+	Subtitle   string
+	XAxisTitle string
+	YAxisTitle string
+	Caption    string // footnote at bottom left
+	Source     string // data source at bottom right
+
+	// styles, taken from Theme if not set
+	Classes        bool   // emit class names styled by StyleSheet instead of inline styles, DrawAt expects the document to include it
+	StyleSheetURL  string // with Classes, Draw imports this CSS instead of embedding StyleSheet of Theme
+	Theme          *Theme // DefaultTheme is used if not set
+	Gstyle         string
+	LineXStyle     string
+	GridStyle      string
+	MinorGridStyle string
+	BarStyle       string
+	BarCapStyle    string // translucent circle at bar end
+	ValueStyle     string
 }
 
-// Draw main entry.
-func (chart *HBarChart) Draw() error {
-	canvas := chart.Svg
-	if chart.Svg == nil {
-		return fmt.Errorf("Missing pointer to svg.SVG in field Svg.")
-	}
-	if chart.Width < 10 || chart.Height < 10 {
-		return fmt.Errorf("Incorrect Width or Height value.")
+// Validate checks that all required chart fields are set.
+func (chart *HBarChart) Validate() error {
+	if err := validateCanvas(chart.Svg, chart.Width, chart.Height); err != nil {
+		return err
 	}
 	if len(chart.BarValues) == 0 {
 		return fmt.Errorf("Missing BarValues for the chart.")
 	}
-	if chart.MaxValue == 0 {
-		return fmt.Errorf("Missing chart MaxValue.")
-	}
 	if len(chart.BarValues) != len(chart.LabelsY) {
 		return fmt.Errorf("Number of BarValues does not match number of LabelY.")
 	}
-	// default to sensible constants if value is not set
+	if err := validateValues("BarValues", chart.BarValues); err != nil {
+		return err
+	}
+	if !finite(chart.MinValue, chart.MaxValue) {
+		return fmt.Errorf("Min and max values must be finite numbers.")
+	}
+	if err := validateScale(chart.ScaleX, "BarValues", chart.MinValue, chart.BarValues); err != nil {
+		return err
+	}
+	return validateDomain("BarValues", chart.scale(0, 1))
+}
+
+// SetDefaults sets sensible constants for optional fields that are not set.
+func (chart *HBarChart) SetDefaults() {
+	theme := stylesOf(chart.Theme, chart.Classes)
 	if chart.LineXStyle == "" {
-		chart.LineXStyle = HBarLineXStyle
+		chart.LineXStyle = theme.AxisStyle()
+	}
+	if chart.GridStyle == "" {
+		chart.GridStyle = theme.GridStyle()
+	}
+	if chart.MinorGridStyle == "" {
+		chart.MinorGridStyle = theme.MinorGridStyle()
 	}
 	if chart.Gstyle == "" {
-		chart.Gstyle = HBarGstyle
+		chart.Gstyle = theme.Gstyle()
 	}
-	if chart.GutterRight == 0 {
-		chart.GutterRight = HBarGutterRight
+	if chart.BarStyle == "" {
+		chart.BarStyle = theme.FillStyle(0)
+	}
+	if chart.BarCapStyle == "" {
+		chart.BarCapStyle = theme.BarCapStyle()
 	}
 	if chart.BarSpacing == 0 {
 		chart.BarSpacing = HBarSpacing
 	}
-	if chart.GutterLeft == 0 {
-		chart.GutterLeft = HBarGutterLeft
+	if chart.ValueLabels == ValueLabelDefault {
+		chart.ValueLabels = ValueLabelOutside
+	}
+	if chart.ValueFormat == nil {
+		chart.ValueFormat = chart.FormatX
+	}
+	if chart.ValueFormat == nil {
+		chart.ValueFormat = formatValue
 	}
+	if chart.ValueStyle == "" {
+		chart.ValueStyle = ValueStyle
+	}
+}
 
-	// start SVG
-	canvas.Start(chart.Width, chart.Height)
-	canvas.Gstyle(chart.Gstyle)
-	x, y := chart.GutterLeft, 5
-	bWidth := float64(chart.Width - chart.GutterRight - x)
+// layout returns gutters of the chart, gutters that are not set are sized so
+// left gutter fits bar labels and right gutter fits value text outside of the
+// longest bar and last bottom label.
+func (chart *HBarChart) layout() chartLayout {
+	size := fontSize(chart.Theme)
+	l := chartLayout{gutterLeft: chart.GutterLeft, gutterRight: chart.GutterRight}
+	if l.gutterLeft == 0 {
+		// long labels are cut so bars keep at least two thirds of the chart
+		width := maxTextWidth(chart.LabelsY, size)
+		if max := float64(chart.Width / 3); width > max {
+			width = max
+		}
+		l.gutterLeft = int(math.Ceil(width)) + 5 + layoutPadding
+	}
+	if l.gutterRight == 0 {
+		var texts []string
+		for _, value := range chart.BarValues {
+			if value > 0 && chart.ValueLabels == ValueLabelOutside {
+				texts = append(texts, chart.ValueFormat(value))
+			}
+		}
+		// value text starts inset+2 right of bar end
+		right := int(maxTextWidth(texts, size*labelScale)) + chart.BarSpacing/4 + 2 + layoutPadding
+		_, labels := axisTicks(chart.scale(0, 1), chart.LabelsX, chart.FormatX)
+		if overhang := xLabelOverhang(labels, size); overhang > right {
+			right = overhang
+		}
+		l.gutterRight = right
+	}
+	return l
+}
+
+// Draw produces chart on screen, main entry point.
+func (chart *HBarChart) Draw() error {
+	return drawChart(chart)
+}
+
+// DrawAt draws chart into its own viewport at x, y of existing SVG document
+// without starting or ending it.
+func (chart *HBarChart) DrawAt(x, y int) error {
+	return drawChartAt(chart, x, y, false)
+}
+
+// SetCanvas points chart to canvas and sets chart size.
+func (chart *HBarChart) SetCanvas(canvas *svg.SVG, width, height int) {
+	chart.Svg = canvas
+	chart.Width, chart.Height = width, height
+}
+
+// frame returns canvas of the chart and styles of its document.
+func (chart *HBarChart) frame() chartFrame {
+	return chartFrame{chart.Svg, chart.Width, chart.Height, chart.Gstyle, chart.Theme, chart.Classes,
+		chart.StyleSheetURL}
+}
+
+// seriesCount returns number of series styled by style sheet.
+func (chart *HBarChart) seriesCount() int {
+	return 1
+}
+
+// copy returns copy of chart for single draw, classes turns on class names.
+func (chart *HBarChart) copy(classes bool) drawer {
+	c := *chart
+	c.Classes = c.Classes || classes
+	return &c
+}
 
+// draw renders chart body.
+func (chart *HBarChart) draw() error {
+	l := chart.layout()
+	canvas := chart.Svg
+	titles := chart.titles()
+	titles.draw(canvas, chart.Width, chart.Height)
+	area := titles.area(chart.Width, chart.Height)
+	x, y := area.left+l.gutterLeft, area.top+5
+	right := area.right - l.gutterRight
+	scale := chart.scale(float64(x), float64(right))
+	zero := int(scale.Base())
+
+	// gridlines go behind bars, horizontal ones separate bars
+	gridX, labels := axisTicks(scale, chart.LabelsX, chart.FormatX)
+	var gridY []float64
+	for i := 0; i <= len(chart.BarValues); i++ {
+		gridY = append(gridY, float64(y+i*chart.BarSpacing))
+	}
+	drawGrid(canvas, chart.Grid, gridX, gridY, x, y, right, int(gridY[len(gridY)-1]),
+		chart.GridStyle, chart.MinorGridStyle)
+
+	// labels are cut to fit left gutter
+	labelWidth := float64(l.gutterLeft - 5 - layoutPadding)
 	for i, data := range chart.LabelsY {
 		// scale value to fit in chart pixels
-		val := float64(chart.BarValues[i])
-		chartVal := int((val / float64(chart.MaxValue)) * bWidth)
-		fmt.Printf("%f scaled to %d\n", val, chartVal)
-		chart.drawMeter(x, y, chart.Width-x, chart.BarSpacing, chartVal,
-			chart.BarValues[i], data)
+		chartVal := int(scale.barEnd(chart.BarValues[i])) - zero
+		label := truncateLabel(data, fontSize(chart.Theme), labelWidth)
+		canvas.Text(x-5, y+chart.BarSpacing/2, label, "text-anchor:end;baseline-shift:-33%")
+		chart.drawMeter(zero, y, chart.BarSpacing, chartVal, chart.BarValues[i])
 		y += chart.BarSpacing
 	}
 
-	// bottom line markers and label
-	canvas.Line(x, y+12, chart.Width-chart.GutterRight, y+12, chart.LineXStyle)
-	step := bWidth / 10
-	pos := 0
-	for i := 0.0; i <= bWidth; i += step {
-		marker := x + int(i)
-		if pos == 0 || pos == 5 || pos == 10 {
-			canvas.Line(marker, y+6, marker, y+18, chart.LineXStyle)
-		} else {
-			canvas.Line(marker, y+9, marker, y+15, chart.LineXStyle)
-		}
-		pos += 1
-	}
-	// display bottom line labels
-	labels := len(chart.LabelsX)
-	for i := 0; i < labels; i++ {
-		step := bWidth / float64(labels-1)
-		xoffset := int(float64(i) * step)
-		canvas.Text(x+xoffset, y+30, chart.LabelsX[i], "font-size:75%;text-anchor:middle;")
-	}
-	canvas.Gend()
-	canvas.End()
+	// bottom line markers and labels
+	drawXLine(canvas, y+12, x, right, gridX, labels, chart.LineXStyle)
 	return nil
 }
 
-// drawMeter draw bar on screen.
-func (chart *HBarChart) drawMeter(x, y, w, h, value, origValue int, label string) {
+// scale returns scale of bar values between from and to.
+func (chart *HBarChart) scale(from, to float64) Scale {
+	return axisScale(chart.ScaleX, chart.MinValue, chart.MaxValue, chart.BarValues, from, to)
+}
+
+// titles returns chart titles, X axis title goes under value line and Y axis
+// title is next to bar labels.
+func (chart *HBarChart) titles() chartTitles {
+	return chartTitles{title: chart.Title, subtitle: chart.Subtitle, xAxis: chart.XAxisTitle,
+		yAxis: chart.YAxisTitle, caption: chart.Caption, source: chart.Source}
+}
+
+// drawMeter draw bar on screen, bar starts at zero position x and grows
+// right for positive values and left for negative ones.
+func (chart *HBarChart) drawMeter(x, y, h, value int, origValue float64) {
 	canvas := chart.Svg
 	corner := h / 2
 	inset := corner / 2
-	canvas.Text(x-5, y+h/2, label, "text-anchor:end;baseline-shift:-33%")
-	canvas.Roundrect(x, y+inset, value, h-(inset*2),
-		inset, inset, "fill:darkgray")
-	if value > 9 {
+	left, width := x, value
+	if value < 0 {
+		left, width = x+value, -value
+	}
+	canvas.Roundrect(left, y+inset, width, h-(inset*2),
+		inset, inset, chart.BarStyle)
+	if value < 0 {
+		if width > 9 {
+			canvas.Circle(left+inset, y+corner, inset, chart.BarCapStyle)
+		}
+	} else if width > 9 {
 		// draw inset circle only if value is not too small
-		canvas.Circle(x+inset+value-corner, y+corner, inset, "fill:red;fill-opacity:0.3")
+		canvas.Circle(x+inset+value-corner, y+corner, inset, chart.BarCapStyle)
+	}
+	if chart.ValueLabels != ValueLabelNone {
+		drawHBarValue(canvas, x, y, h, value, inset+2, chart.ValueFormat(origValue), chart.ValueLabels,
+			fontSize(chart.Theme), chart.ValueStyle)
 	}
-	canvas.Text(x+inset+value+2, y+h/2, fmt.Sprintf("%-3d", origValue),
-		"font-size:75%;text-anchor:start;baseline-shift:-33%")
 }
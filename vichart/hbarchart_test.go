@@ -0,0 +1,34 @@
+// ViChart library for Go
+// Author: Tad Vizbaras 
+// License: http://github.com/tadvi/vichart/blob/master/LICENSE 
+//
+package vichart
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/ajstarks/svgo"
+)
+
+func TestHBarCaps(t *testing.T) {
+	var buf bytes.Buffer
+	chart := HBarChart{Svg: svg.New(&buf), Width: 400, Height: 300, BarValues: []float64{3, -2},
+		LabelsY: []string{"a", "b"}, Theme: &DarkTheme}
+	caps := named(render(t, &chart, &buf), "circle")
+	if len(caps) != 2 {
+		t.Fatalf("draws %d caps, want 2", len(caps))
+	}
+	for i, c := range caps {
+		if c.attrs["style"] != DarkTheme.BarCapStyle() {
+			t.Errorf("cap %d has style %q, want %q", i, c.attrs["style"], DarkTheme.BarCapStyle())
+		}
+	}
+	chart.Classes = true
+	for i, c := range named(render(t, &chart, &buf), "circle") {
+		if c.attrs["class"] != "vichart-bar-cap" || c.attrs["style"] != "" {
+			t.Errorf("cap %d with classes has class %q and style %q, want class vichart-bar-cap only",
+				i, c.attrs["class"], c.attrs["style"])
+		}
+	}
+}
@@ -0,0 +1,191 @@
+// ViChart library for Go
+// Author: Tad Vizbaras 
+// License: http://github.com/tadvi/vichart/blob/master/LICENSE 
+//
+package vichart
+
+import (
+	"fmt"
+	"github.com/ajstarks/svgo"
+	"math"
+	"strings"
+)
+
+// LabelFit selects how category labels are drawn when they do not fit next
+// to each other under the bars.
+type LabelFit int
+
+const (
+	LabelFitAuto     LabelFit = iota // first of plain, wrap, rotate 45, rotate 90 and thin that fits
+	LabelFitNone                     // labels are drawn as they are even if they overlap
+	LabelFitWrap                     // long labels are wrapped onto multiple lines
+	LabelFitRotate45                 // labels are rotated 45 degrees
+	LabelFitRotate90                 // labels are rotated 90 degrees
+	LabelFitThin                     // only every Nth label is drawn
+)
+
+const (
+	labelGap        = 4   // min space between neighbour labels
+	labelMaxLines   = 3   // wrapped labels longer than this do not fit
+	labelLineHeight = 1.2 // line height of wrapped labels relative to label size
+)
+
+// labelFit is resolved way of drawing category labels.
+type labelFit struct {
+	fit    LabelFit   // never LabelFitAuto
+	step   int        // every step-th label is drawn
+	lines  [][]string // lines of every label, single line unless wrapped
+	height int        // space taken below bar base by X line and labels
+}
+
+// fitLabels resolves how labels drawn with font size are placed when they are
+// slot pixels apart. Rotated labels taller than maxHeight do not fit in auto
+// mode.
+func fitLabels(labels []string, size, slot float64, fit LabelFit, maxHeight int) labelFit {
+	result := labelFit{fit: fit, step: 1, lines: make([][]string, len(labels)), height: xAxisHeight(size)}
+	for i, label := range labels {
+		result.lines[i] = []string{label}
+	}
+	labelSize := size * labelScale
+	width := maxTextWidth(labels, labelSize)
+	lineHeight := labelSize * labelLineHeight
+
+	if fit == LabelFitAuto {
+		switch {
+		case width+labelGap <= slot:
+			fit = LabelFitNone
+		case wrapFits(labels, labelSize, slot-labelGap):
+			fit = LabelFitWrap
+		case slot*math.Sin(math.Pi/4) >= lineHeight && rotatedHeight(width, labelSize, 45) <= maxHeight:
+			fit = LabelFitRotate45
+		case slot >= lineHeight && rotatedHeight(width, labelSize, 90) <= maxHeight:
+			fit = LabelFitRotate90
+		default:
+			fit = LabelFitThin
+		}
+		result.fit = fit
+	}
+
+	switch fit {
+	case LabelFitWrap:
+		lines := 1
+		for i, label := range labels {
+			result.lines[i] = wrapLabel(label, labelSize, slot-labelGap)
+			if len(result.lines[i]) > lines {
+				lines = len(result.lines[i])
+			}
+		}
+		result.height += int(float64(lines-1) * lineHeight)
+	case LabelFitRotate45:
+		result.height = rotatedHeight(width, labelSize, 45)
+	case LabelFitRotate90:
+		result.height = rotatedHeight(width, labelSize, 90)
+	case LabelFitThin:
+		for slot > 0 && float64(result.step)*slot < width+labelGap {
+			result.step++
+		}
+	}
+	return result
+}
+
+// labelCenters returns X positions of count labels spread over bar centers
+// from the first to the last bar.
+func labelCenters(centers []int, count int) []float64 {
+	pos := make([]float64, count)
+	for i := range pos {
+		bar := 0
+		if count > 1 {
+			bar = i * (len(centers) - 1) / (count - 1)
+		}
+		pos[i] = float64(centers[bar])
+	}
+	return pos
+}
+
+// labelSlot returns distance between count labels spread over bar centers,
+// slot is distance between bars.
+func labelSlot(centers []int, count int, slot float64) float64 {
+	if count > 1 && len(centers) > 1 {
+		return float64(centers[len(centers)-1]-centers[0]) / float64(count-1)
+	}
+	return slot
+}
+
+// rotatedHeight returns space below bar base taken by X line and labels of
+// width rotated by angle in degrees.
+func rotatedHeight(width, size, angle float64) int {
+	a := angle * math.Pi / 180
+	return 22 + int(width*math.Sin(a)+size*math.Cos(a)/2) + layoutPadding
+}
+
+// wrapFits reports if every label wraps into at most labelMaxLines lines no
+// wider than width.
+func wrapFits(labels []string, size, width float64) bool {
+	for _, label := range labels {
+		lines := wrapLabel(label, size, width)
+		if len(lines) > labelMaxLines || maxTextWidth(lines, size) > width {
+			return false
+		}
+	}
+	return true
+}
+
+// wrapLabel breaks label at spaces into lines no wider than width, single
+// word wider than width stays on its own line.
+func wrapLabel(label string, size, width float64) []string {
+	var lines []string
+	line := ""
+	for _, word := range strings.Fields(label) {
+		if line != "" && textWidth(line+" "+word, size) > width {
+			lines = append(lines, line)
+			line = ""
+		}
+		if line != "" {
+			line += " "
+		}
+		line += word
+	}
+	return append(lines, line)
+}
+
+// truncateLabel cuts label with ellipsis so it is no wider than width.
+func truncateLabel(label string, size, width float64) string {
+	if textWidth(label, size) <= width {
+		return label
+	}
+	runes := []rune(label)
+	for len(runes) > 0 && textWidth(string(runes)+"…", size) > width {
+		runes = runes[:len(runes)-1]
+	}
+	return strings.TrimSpace(string(runes)) + "…"
+}
+
+// drawBarLabels draws horizontal X line at y with markers and labels under bar
+// centers. When there are fewer labels than bars, labels are spread over bars
+// from the first to the last one. Labels are wrapped, rotated or thinned as
+// resolved by fitLabels.
+func drawBarLabels(canvas *svg.SVG, y, from, to int, centers []int, labels []string, fit labelFit, size float64, style string) {
+	canvas.Line(from, y, to, y, style)
+
+	lineHeight := int(size * labelScale * labelLineHeight)
+	for i, p := range labelCenters(centers, len(labels)) {
+		xpos := int(p)
+		canvas.Line(xpos, y-6, xpos, y+6, style)
+		if i%fit.step != 0 {
+			continue
+		}
+		switch fit.fit {
+		case LabelFitRotate45, LabelFitRotate90:
+			angle := 45
+			if fit.fit == LabelFitRotate90 {
+				angle = 90
+			}
+			canvas.Text(xpos, y+10, labels[i], joinStyles("font-size:75%;text-anchor:end;baseline-shift:-33%",
+				fmt.Sprintf(`transform="rotate(-%d,%d,%d)"`, angle, xpos, y+10)))
+		default:
+			for j, line := range fit.lines[i] {
+				canvas.Text(xpos, y+18+j*lineHeight, line, "font-size:75%;text-anchor:middle;")
+			}
+		}
+	}
+}
@@ -0,0 +1,135 @@
+// ViChart library for Go
+// Author: Tad Vizbaras 
+// License: http://github.com/tadvi/vichart/blob/master/LICENSE 
+//
+package vichart
+
+import (
+	"strings"
+)
+
+const (
+	labelScale    = 0.75 // tick, legend and value labels are drawn at 75% of font size
+	layoutPadding = 6    // space kept between measured text and chart edge
+	legendHeight  = 24   // height of legend row above plot
+	yLabelOffset  = 16   // distance of Y line labels from plot edge
+)
+
+// chartLayout is gutters, legend offset and pie radius resolved for single
+// draw. Values set on chart are taken as they are and the rest is computed,
+// so chart fields are never changed and chart can be redrawn at other size.
+type chartLayout struct {
+	gutterLeft, gutterRight, gutterTop int
+	legendX                            int // legend offset, from plot left edge or from chart left edge for pie
+	radius                             int // pie radius
+}
+
+// character classes of proportional fonts with their width relative to font size
+const (
+	narrowChars = "iljtfrI!|.,:;'` ()[]"
+	wideChars   = "mwMW@%"
+)
+
+// charWidth returns approximate width of character relative to font size.
+func charWidth(r rune) float64 {
+	switch {
+	case strings.ContainsRune(narrowChars, r):
+		return 0.3
+	case strings.ContainsRune(wideChars, r):
+		return 0.85
+	case r >= 'A' && r <= 'Z':
+		return 0.62
+	case r > 0x2e80:
+		// CJK and other full width characters
+		return 1
+	}
+	return 0.52
+}
+
+// textWidth returns approximate width in pixels of text drawn with font size.
+func textWidth(text string, size float64) float64 {
+	width := 0.0
+	for _, r := range text {
+		width += charWidth(r) * size
+	}
+	return width
+}
+
+// maxTextWidth returns approximate width of the widest text.
+func maxTextWidth(texts []string, size float64) float64 {
+	max := 0.0
+	for _, text := range texts {
+		if w := textWidth(text, size); w > max {
+			max = w
+		}
+	}
+	return max
+}
+
+// fontSize returns font size of theme, labels are drawn at labelScale of it.
+func fontSize(theme *Theme) float64 {
+	return float64(themeOf(theme).fontSize())
+}
+
+// xAxisHeight returns space below plot taken by X line, markers and labels
+// drawn with label font size.
+func xAxisHeight(size float64) int {
+	// labels are 30px below plot and descend quarter of their size
+	return 30 + int(size*labelScale/4) + layoutPadding
+}
+
+// legendWidth returns width of legend row with items.
+func legendWidth(items []legendItem, size float64) int {
+	width := 0
+	for _, item := range items {
+		width += legendEntryWidth(item, size)
+	}
+	return width - legendGap
+}
+
+// legendEntryWidth returns width of single legend entry including gap after it.
+func legendEntryWidth(item legendItem, size float64) int {
+	return 50 + int(textWidth(item.label, size*labelScale)) + legendGap
+}
+
+// centerLegend returns legend offset from plot left edge that centers legend
+// over plot, legend wider than plot starts at plot left edge.
+func centerLegend(items []legendItem, size float64, plotWidth int) int {
+	offset := (plotWidth - legendWidth(items, size)) / 2
+	if offset < 0 {
+		return 0
+	}
+	return offset
+}
+
+// yGutter returns gutter width that fits Y line with labels.
+func yGutter(labels []string, size float64) int {
+	return yLabelOffset + int(maxTextWidth(labels, size*labelScale)) + layoutPadding
+}
+
+// xLabelOverhang returns how far last X label centered at plot edge reaches past it.
+func xLabelOverhang(labels []string, size float64) int {
+	if len(labels) == 0 {
+		return layoutPadding
+	}
+	return int(textWidth(labels[len(labels)-1], size*labelScale)/2) + layoutPadding
+}
+
+// barLabelOverhang returns how far last X label centered under last bar reaches
+// past plot edge, slot is width taken by every bar.
+func barLabelOverhang(labels []string, size, slot float64) int {
+	overhang := xLabelOverhang(labels, size) - int(slot/2)
+	if overhang < layoutPadding {
+		return layoutPadding
+	}
+	return overhang
+}
+
+// topGutter returns space above plot for legend row and half of top Y label.
+func topGutter(legend bool, size float64) int {
+	top := int(size*labelScale/2) + layoutPadding
+	if legend {
+		top += legendHeight
+	}
+	return top
+}
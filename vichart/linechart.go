@@ -0,0 +1,391 @@
+// ViChart library for Go
+// Author: Tad Vizbaras 
+// License: http://github.com/tadvi/vichart/blob/master/LICENSE 
+//
+package vichart
+
+import (
+	"fmt"
+	"github.com/ajstarks/svgo"
+	"math"
+	"time"
+)
+
+const (
+	LineMarkerSize = 3
+)
+
+// Deprecated: LineChart takes its default styles from Theme, series
+// colors come from Theme palette.
+const (
+	LineGstyle       = "font-family:Calibri; font-size:14"
+	LineLineXYStyle  = "stroke:lightgray;stroke-width:2px;"
+	LineSeriesStyle1 = "fill:none;stroke:navy;stroke-width:2px;"
+	LineSeriesStyle2 = "fill:none;stroke:red;stroke-width:2px;"
+	LineSeriesStyle3 = "fill:none;stroke:green;stroke-width:2px;"
+	LineSeriesStyle4 = "fill:none;stroke:orange;stroke-width:2px;"
+	LineSeriesStyle5 = "fill:none;stroke:teal;stroke-width:2px;"
+	LineSeriesStyle6 = "fill:none;stroke:gray;stroke-width:2px;"
+)
+
+// Deprecated: gutters and legend offset that are not set are sized from
+// labels, legend and chart size.
+const (
+	LineGutterLeft    = 40
+	LineGutterRight   = 20
+	LineGutterTop     = 40
+	LineLegendXOffset = 10
+)
+
+// Marker is shape drawn at every point of line series.
+type Marker int
+
+const (
+	MarkerNone Marker = iota
+	MarkerCircle
+	MarkerSquare
+)
+
+// LineSeries is single named line on LineChart.
+type LineSeries struct {
+	Name   string
+	Values []float64   // Y values
+	X      []float64   // optional X values, point index is used if not set
+	Times  []time.Time // optional time of every point, used with time X line instead of X
+
+	// optional fields below
+	Style       string // line style, theme palette is cycled if not set
+	Marker      Marker
+	MarkerStyle string // marker style, background fill with line stroke if not set
+}
+
+type LineChart struct {
+	Svg           *svg.SVG
+	Width, Height int
+	Series        []LineSeries
+
+	// optional fields below
+	MinValue, MaxValue float64   // Y range, computed from series values if not set
+	LabelsX            []string  // spread evenly along X line, generated from X values if not set, not used with Times
+	LabelsY            []string  // spread evenly along Y line, generated from values if not set
+	FormatX            Formatter // formats generated X line labels, with precision of tick step if not set
+	FormatY            Formatter // formats generated Y line labels, with precision of tick step if not set
+	ScaleX             ScaleType // scale of X values, linear if not set, not used with Times
+	ScaleY             ScaleType // scale of values, linear if not set
+	GutterLeft         int       // left gutter for the chart, used to fit left labels
+	GutterRight        int       // right gutter for the chart, used to fit last bottom label
+	GutterTop          int       // top gutter for the chart, used for legend
+	MarkerSize         int
+	TimeFormat         string // time layout for X labels when series have Times, picked by tick interval if not set
+	Grid               Grid   // gridlines drawn behind series, none if not set
+
+	// titles, plot area shrinks to make room for them
+	Title      string
+	Subtitle   string
+	XAxisTitle string
+	YAxisTitle string
+	Caption    string // footnote at bottom left
+	Source     string // data source at bottom right
+
+	// styles, taken from Theme if not set
+	Classes        bool   // emit class names styled by StyleSheet instead of inline styles, DrawAt expects the document to include it
+	StyleSheetURL  string // with Classes, Draw imports this CSS instead of embedding StyleSheet of Theme
+	Theme          *Theme // DefaultTheme is used if not set
+	Gstyle         string
+	LineXYStyle    string
+	GridStyle      string
+	MinorGridStyle string
+
+	// legend offset
+	LegendXOffset int
+}
+
+// Validate checks that all required chart fields are set.
+func (chart *LineChart) Validate() error {
+	if err := validateCanvas(chart.Svg, chart.Width, chart.Height); err != nil {
+		return err
+	}
+	if len(chart.Series) == 0 {
+		return fmt.Errorf("Missing Series for the chart.")
+	}
+	if !finite(chart.MinValue, chart.MaxValue) {
+		return fmt.Errorf("Min and max values must be finite numbers.")
+	}
+	for i, series := range chart.Series {
+		if len(series.Values) == 0 {
+			return fmt.Errorf("Missing Values for series %d.", i)
+		}
+		if len(series.X) > 0 && len(series.X) != len(series.Values) {
+			return fmt.Errorf("Number of X values does not match number of Values for series %d.", i)
+		}
+		if len(series.Times) > 0 && len(series.Times) != len(series.Values) {
+			return fmt.Errorf("Number of Times does not match number of Values for series %d.", i)
+		}
+		if (len(series.Times) > 0) != chart.timed() {
+			return fmt.Errorf("Either all series or none of them should have Times.")
+		}
+		if err := validateValues(fmt.Sprintf("Values of series %d", i), series.Values); err != nil {
+			return err
+		}
+		if err := validateValues(fmt.Sprintf("X values of series %d", i), series.X); err != nil {
+			return err
+		}
+		if err := validateScale(chart.ScaleY, "Values", chart.MinValue, series.Values); err != nil {
+			return err
+		}
+		if chart.timed() {
+			continue
+		}
+		// points without X values are placed from zero
+		xs := make([]float64, len(series.Values))
+		for i := range xs {
+			xs[i] = series.xValue(i)
+		}
+		if err := validateScale(chart.ScaleX, "X values", 0, xs); err != nil {
+			return err
+		}
+	}
+	if err := validateDomain("Values", chart.yScale(0, 1)); err != nil {
+		return err
+	}
+	if !chart.timed() {
+		return validateDomain("X values", chart.xScale(0, 1))
+	}
+	return nil
+}
+
+// SetDefaults sets sensible constants for optional fields that are not set.
+func (chart *LineChart) SetDefaults() {
+	theme := stylesOf(chart.Theme, chart.Classes)
+	for i := range chart.Series {
+		series := &chart.Series[i]
+		if series.Style == "" {
+			series.Style = theme.LineStyle(i)
+		}
+		if series.MarkerStyle == "" {
+			series.MarkerStyle = joinStyles(series.Style, theme.MarkerStyle(i))
+		}
+	}
+	if chart.Gstyle == "" {
+		chart.Gstyle = theme.Gstyle()
+	}
+	if chart.LineXYStyle == "" {
+		chart.LineXYStyle = theme.AxisStyle()
+	}
+	if chart.GridStyle == "" {
+		chart.GridStyle = theme.GridStyle()
+	}
+	if chart.MinorGridStyle == "" {
+		chart.MinorGridStyle = theme.MinorGridStyle()
+	}
+	if chart.MarkerSize == 0 {
+		chart.MarkerSize = LineMarkerSize
+	}
+}
+
+// layout returns gutters and legend offset of the chart, those that are not
+// set are sized from labels, legend and titles, so chart fits its size
+// without manual tweaking.
+func (chart *LineChart) layout() chartLayout {
+	size := fontSize(chart.Theme)
+	l := chartLayout{gutterLeft: chart.GutterLeft, gutterRight: chart.GutterRight, gutterTop: chart.GutterTop,
+		legendX: chart.LegendXOffset}
+	if l.gutterLeft == 0 {
+		_, labels := axisTicks(chart.yScale(1, 0), chart.LabelsY, chart.FormatY)
+		l.gutterLeft = yGutter(labels, size)
+	}
+	if l.gutterRight == 0 {
+		_, _, labels := chart.xAxis(0, 1)
+		l.gutterRight = xLabelOverhang(labels, size)
+	}
+	if l.gutterTop == 0 {
+		l.gutterTop = topGutter(len(chart.legendItems()) > 0, size)
+	}
+	if l.legendX == 0 {
+		area := chart.titles().area(chart.Width, chart.Height)
+		plotWidth := area.right - area.left - l.gutterLeft - l.gutterRight
+		l.legendX = centerLegend(chart.legendItems(), size, plotWidth)
+	}
+	return l
+}
+
+// Draw produces chart on screen, main entry point.
+func (chart *LineChart) Draw() error {
+	return drawChart(chart)
+}
+
+// DrawAt draws chart into its own viewport at x, y of existing SVG document
+// without starting or ending it.
+func (chart *LineChart) DrawAt(x, y int) error {
+	return drawChartAt(chart, x, y, false)
+}
+
+// SetCanvas points chart to canvas and sets chart size.
+func (chart *LineChart) SetCanvas(canvas *svg.SVG, width, height int) {
+	chart.Svg = canvas
+	chart.Width, chart.Height = width, height
+}
+
+// frame returns canvas of the chart and styles of its document.
+func (chart *LineChart) frame() chartFrame {
+	return chartFrame{chart.Svg, chart.Width, chart.Height, chart.Gstyle, chart.Theme, chart.Classes,
+		chart.StyleSheetURL}
+}
+
+// seriesCount returns number of series styled by style sheet.
+func (chart *LineChart) seriesCount() int {
+	return len(chart.Series)
+}
+
+// copy returns copy of chart for single draw, classes turns on class names.
+// Series are copied too as SetDefaults fills in their styles.
+func (chart *LineChart) copy(classes bool) drawer {
+	c := *chart
+	c.Classes = c.Classes || classes
+	c.Series = append(c.Series[:0:0], c.Series...)
+	return &c
+}
+
+// draw renders chart body.
+func (chart *LineChart) draw() error {
+	l := chart.layout()
+	canvas := chart.Svg
+	titles := chart.titles()
+	titles.draw(canvas, chart.Width, chart.Height)
+	area := titles.area(chart.Width, chart.Height)
+	x, y := area.left+l.gutterLeft, area.bottom-xAxisHeight(fontSize(chart.Theme))
+	right := area.right - l.gutterRight
+
+	yScale := chart.yScale(float64(y), float64(area.top+l.gutterTop))
+	xPos, pos, labels := chart.xAxis(float64(x), float64(right))
+
+	// gridlines go behind series
+	gridY, _ := axisTicks(yScale, chart.LabelsY, chart.FormatY)
+	drawGrid(canvas, chart.Grid, pos, gridY, x, int(yScale.To), right, int(yScale.From),
+		chart.GridStyle, chart.MinorGridStyle)
+
+	// zero baseline when values go below zero
+	if yScale.Min < 0 {
+		zero := int(yScale.Map(0))
+		canvas.Line(x, zero, right, zero, chart.LineXYStyle)
+	}
+
+	for _, series := range chart.Series {
+		xs := make([]int, len(series.Values))
+		ys := make([]int, len(series.Values))
+		for i, val := range series.Values {
+			xs[i] = int(xPos(series, i))
+			ys[i] = int(yScale.Map(val))
+		}
+		canvas.Polyline(xs, ys, series.Style)
+		for i := range xs {
+			chart.drawMarker(xs[i], ys[i], series)
+		}
+	}
+
+	// bottom line markers and labels
+	drawXLine(canvas, y+12, x, right, pos, labels, chart.LineXYStyle)
+	// left vertical Y line
+	pos, labels = axisTicks(yScale, chart.LabelsY, chart.FormatY)
+	drawYLine(canvas, x, yScale, pos, chart.LineXYStyle)
+	drawYLineText(canvas, x-16, pos, labels, true)
+
+	chart.drawLegend(x+l.legendX, area.top)
+	return nil
+}
+
+// titles returns chart titles.
+func (chart *LineChart) titles() chartTitles {
+	return chartTitles{title: chart.Title, subtitle: chart.Subtitle, xAxis: chart.XAxisTitle,
+		yAxis: chart.YAxisTitle, caption: chart.Caption, source: chart.Source}
+}
+
+// yScale returns scale of values of all series between from and to.
+func (chart *LineChart) yScale(from, to float64) Scale {
+	var values []float64
+	for _, series := range chart.Series {
+		values = append(values, series.Values...)
+	}
+	return axisScale(chart.ScaleY, chart.MinValue, chart.MaxValue, values, from, to)
+}
+
+// xAxis returns X position of point i in series for X line between from and to
+// together with positions and labels of X line markers.
+func (chart *LineChart) xAxis(from, to float64) (xPos func(series LineSeries, i int) float64, pos []float64, labels []string) {
+	if chart.timed() {
+		var times []time.Time
+		for _, series := range chart.Series {
+			times = append(times, series.Times...)
+		}
+		min, max := timeRange(times)
+		xScale := NewTimeScale(min, max, from, to)
+		xPos = func(series LineSeries, i int) float64 { return xScale.Map(series.Times[i]) }
+		pos, labels = timeAxisTicks(xScale, chart.TimeFormat)
+		return xPos, pos, labels
+	}
+	xScale := chart.xScale(from, to)
+	xPos = func(series LineSeries, i int) float64 { return xScale.Map(series.xValue(i)) }
+	pos, labels = axisTicks(xScale, chart.LabelsX, chart.FormatX)
+	return xPos, pos, labels
+}
+
+// xScale returns scale of X values of all series between from and to.
+func (chart *LineChart) xScale(from, to float64) Scale {
+	min, max := chart.xRange()
+	scale := NewScale(ScaleLinear, min, max, from, to, false)
+	scale.Type = chart.ScaleX
+	return scale
+}
+
+// timed reports if series are placed by time.
+func (chart *LineChart) timed() bool {
+	return len(chart.Series[0].Times) > 0
+}
+
+// xRange returns X range covered by all series.
+func (chart *LineChart) xRange() (min, max float64) {
+	min, max = math.Inf(1), math.Inf(-1)
+	for _, series := range chart.Series {
+		for i := range series.Values {
+			min = math.Min(min, series.xValue(i))
+			max = math.Max(max, series.xValue(i))
+		}
+	}
+	return min, max
+}
+
+// xValue returns X value of point i.
+func (series LineSeries) xValue(i int) float64 {
+	if len(series.X) > 0 {
+		return series.X[i]
+	}
+	return float64(i)
+}
+
+// drawMarker draws series marker centered at x, y.
+func (chart *LineChart) drawMarker(x, y int, series LineSeries) {
+	canvas := chart.Svg
+	size := chart.MarkerSize
+	switch series.Marker {
+	case MarkerCircle:
+		canvas.Circle(x, y, size, series.MarkerStyle)
+	case MarkerSquare:
+		canvas.Rect(x-size, y-size, size*2, size*2, series.MarkerStyle)
+	}
+}
+
+// legendItems returns legend entry for every named series.
+func (chart *LineChart) legendItems() []legendItem {
+	var items []legendItem
+	for _, series := range chart.Series {
+		if series.Name != "" {
+			items = append(items, legendItem{label: series.Name, style: series.Style, line: true})
+		}
+	}
+	return items
+}
+
+// drawLegend draws legend entry for every named series.
+func (chart *LineChart) drawLegend(x, y int) {
+	drawLegend(chart.Svg, x, y, fontSize(chart.Theme), chart.legendItems())
+}
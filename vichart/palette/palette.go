@@ -0,0 +1,152 @@
+// ViChart library for Go
+// Author: Tad Vizbaras 
+// License: http://github.com/tadvi/vichart/blob/master/LICENSE 
+//
+package palette
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+)
+
+// Palette is list of categorical colors in "#rrggbb" form, it can be used
+// as vichart.Theme palette directly.
+type Palette []string
+
+// categorical palettes
+var (
+	// OkabeIto is colorblind safe palette by Masataka Okabe and Kei Ito.
+	OkabeIto = Palette{"#e69f00", "#56b4e9", "#009e73", "#f0e442",
+		"#0072b2", "#d55e00", "#cc79a7", "#000000"}
+	// Tableau10 is default categorical palette of Tableau.
+	Tableau10 = Palette{"#4e79a7", "#f28e2b", "#e15759", "#76b7b2", "#59a14f",
+		"#edc948", "#b07aa1", "#ff9da7", "#9c755f", "#bab0ac"}
+)
+
+// Color returns color i, colors are cycled when i is past the end of palette.
+func (p Palette) Color(i int) string {
+	return p[i%len(p)]
+}
+
+// FillStyles returns fill style for every color with stroke color, suitable for
+// PieChart.FillStyles and bar series styles.
+func (p Palette) FillStyles(stroke string) []string {
+	styles := make([]string, len(p))
+	for i, color := range p {
+		styles[i] = fmt.Sprintf("fill:%s;stroke:%s;", color, stroke)
+	}
+	return styles
+}
+
+// LineStyles returns line style for every color, suitable for line series styles.
+func (p Palette) LineStyles() []string {
+	styles := make([]string, len(p))
+	for i, color := range p {
+		styles[i] = fmt.Sprintf("fill:none;stroke:%s;stroke-width:2px;", color)
+	}
+	return styles
+}
+
+// Ramp is continuous color scale given by evenly spaced color stops in
+// "#rrggbb" form, colors between stops are interpolated.
+type Ramp []string
+
+// sequential ramps go from light to dark
+var (
+	Blues   = Ramp{"#f7fbff", "#c6dbef", "#6baed6", "#2171b5", "#08306b"}
+	Greens  = Ramp{"#f7fcf5", "#c7e9c0", "#74c476", "#238b45", "#00441b"}
+	Oranges = Ramp{"#fff5eb", "#fdd0a2", "#fd8d3c", "#d94801", "#7f2704"}
+	Viridis = Ramp{"#440154", "#3b528b", "#21918c", "#5ec962", "#fde725"}
+)
+
+// diverging ramps go through light middle color
+var (
+	RedBlue    = Ramp{"#b2182b", "#ef8a62", "#f7f7f7", "#67a9cf", "#2166ac"}
+	BrownTeal  = Ramp{"#8c510a", "#d8b365", "#f5f5f5", "#5ab4ac", "#01665e"}
+	PurpleGold = Ramp{"#542788", "#998ec3", "#f7f7f7", "#f1a340", "#b35806"}
+)
+
+// At returns color at position t between 0 and 1, t outside of the range is clamped.
+func (r Ramp) At(t float64) string {
+	if len(r) == 1 || t <= 0 || math.IsNaN(t) {
+		return r[0]
+	}
+	if t >= 1 {
+		return r[len(r)-1]
+	}
+	pos := t * float64(len(r)-1)
+	i := int(pos)
+	return mix(r[i], r[i+1], pos-float64(i))
+}
+
+// Colors returns n colors evenly spread over the ramp from first to last stop.
+func (r Ramp) Colors(n int) Palette {
+	colors := make(Palette, n)
+	for i := range colors {
+		if n == 1 {
+			colors[i] = r.At(0.5)
+			continue
+		}
+		colors[i] = r.At(float64(i) / float64(n-1))
+	}
+	return colors
+}
+
+// Generate returns n distinct colors with hue spread by golden angle, so any
+// number of series gets its own color and neighbouring colors differ most.
+func Generate(n int) Palette {
+	colors := make(Palette, n)
+	for i := range colors {
+		colors[i] = hsl(math.Mod(float64(i)*137.508, 360), 0.6, 0.55)
+	}
+	return colors
+}
+
+// mix interpolates colors a and b, t is 0 for a and 1 for b.
+func mix(a, b string, t float64) string {
+	ar, ag, ab := rgb(a)
+	br, bg, bb := rgb(b)
+	return hex(ar+(br-ar)*t, ag+(bg-ag)*t, ab+(bb-ab)*t)
+}
+
+// rgb parses "#rrggbb" color into components between 0 and 255, black is
+// returned for colors in other forms.
+func rgb(color string) (r, g, b float64) {
+	if len(color) != 7 || color[0] != '#' {
+		return 0, 0, 0
+	}
+	v, err := strconv.ParseUint(color[1:], 16, 32)
+	if err != nil {
+		return 0, 0, 0
+	}
+	return float64(v >> 16 & 0xff), float64(v >> 8 & 0xff), float64(v & 0xff)
+}
+
+// hex formats color components as "#rrggbb".
+func hex(r, g, b float64) string {
+	return fmt.Sprintf("#%02x%02x%02x", int(math.Round(r)), int(math.Round(g)), int(math.Round(b)))
+}
+
+// hsl converts hue in degrees, saturation and lightness between 0 and 1 into "#rrggbb".
+func hsl(h, s, l float64) string {
+	c := (1 - math.Abs(2*l-1)) * s
+	x := c * (1 - math.Abs(math.Mod(h/60, 2)-1))
+	m := l - c/2
+	var r, g, b float64
+	switch {
+	case h < 60:
+		r, g, b = c, x, 0
+	case h < 120:
+		r, g, b = x, c, 0
+	case h < 180:
+		r, g, b = 0, c, x
+	case h < 240:
+		r, g, b = 0, x, c
+	case h < 300:
+		r, g, b = x, 0, c
+	default:
+		r, g, b = c, 0, x
+	}
+	return hex((r+m)*255, (g+m)*255, (b+m)*255)
+}
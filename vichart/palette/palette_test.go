@@ -0,0 +1,83 @@
+// ViChart library for Go
+// Author: Tad Vizbaras 
+// License: http://github.com/tadvi/vichart/blob/master/LICENSE 
+//
+package palette
+
+import (
+	"math"
+	"reflect"
+	"regexp"
+	"testing"
+)
+
+func TestRampAt(t *testing.T) {
+	gray := Ramp{"#000000", "#ffffff"}
+	tests := []struct {
+		ramp Ramp
+		t    float64
+		want string
+	}{
+		{gray, 0, "#000000"},
+		{gray, 1, "#ffffff"},
+		{gray, 0.5, "#808080"},
+		{gray, 0.25, "#404040"},
+		{gray, -1, "#000000"},
+		{gray, 2, "#ffffff"},
+		{gray, math.NaN(), "#000000"},
+		{Ramp{"#000000", "#ff0000", "#ffffff"}, 0.5, "#ff0000"},
+		{Ramp{"#000000", "#ff0000", "#ffffff"}, 0.75, "#ff8080"},
+		{Ramp{"#ff0000"}, 0.7, "#ff0000"},
+		{Blues, 0, "#f7fbff"},
+		{Blues, 1, "#08306b"},
+	}
+	for _, tt := range tests {
+		if got := tt.ramp.At(tt.t); got != tt.want {
+			t.Errorf("%v.At(%v) = %q, want %q", tt.ramp, tt.t, got, tt.want)
+		}
+	}
+}
+
+func TestRampColors(t *testing.T) {
+	gray := Ramp{"#000000", "#ffffff"}
+	tests := []struct {
+		n    int
+		want Palette
+	}{
+		{0, Palette{}},
+		{1, Palette{"#808080"}},
+		{3, Palette{"#000000", "#808080", "#ffffff"}},
+	}
+	for _, tt := range tests {
+		if got := gray.Colors(tt.n); !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("Colors(%d) = %q, want %q", tt.n, got, tt.want)
+		}
+	}
+}
+
+func TestGenerate(t *testing.T) {
+	color := regexp.MustCompile("^#[0-9a-f]{6}$")
+	for _, n := range []int{0, 1, 5, 12, 40} {
+		colors := Generate(n)
+		if len(colors) != n {
+			t.Errorf("Generate(%d) returned %d colors", n, len(colors))
+		}
+		seen := map[string]bool{}
+		for i, c := range colors {
+			if !color.MatchString(c) {
+				t.Errorf("Generate(%d) color %d is %q, want #rrggbb", n, i, c)
+			}
+			if seen[c] {
+				t.Errorf("Generate(%d) color %d %q repeats", n, i, c)
+			}
+			seen[c] = true
+		}
+	}
+	// more series keep colors of the first ones
+	if got, want := Generate(12)[:5], Generate(5); !reflect.DeepEqual(got, want) {
+		t.Errorf("Generate(12)[:5] = %q, want %q", got, want)
+	}
+	if got := Generate(1)[0]; got != "#d14747" {
+		t.Errorf("Generate(1)[0] = %q, want %q", got, "#d14747")
+	}
+}
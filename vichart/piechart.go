@@ -8,148 +8,695 @@ import (
 	"fmt"
 	"github.com/ajstarks/svgo"
 	"math"
+	"sort"
+	"vichart/palette"
 )
 
 const (
-	PieGstyle      = "font-family:Calibri; font-size:14"
 	PieStyle = "fill:white;stroke:black;stroke-width:2px;"
-	
-	PieFillStyle1    = "fill:red;stroke:gray;"
-	PieFillStyle2    = "fill:green;stroke:gray;"
-	PieFillStyle3    = "fill:navy;stroke:gray;"
-	PieFillStyle4    = "fill:orange;stroke:gray;"
-	PieFillStyle5    = "fill:gray;stroke:gray;"	
-	PieFillStyle6    = "fill:white;stroke:gray;"	
-	PieFillStyle7    = "fill:blue;stroke:gray;"	
-	PieFillStyle8    = "fill:yellow;stroke:gray;"		
-			
-	PieGutterLeft  = 40
-	PieGutterTop   = 40
-	
-	PieRadius = 80
 
+	PieCenterStyle     = "font-size:150%;text-anchor:middle;"
+	PieCenterSubStyle  = "font-size:75%;text-anchor:middle;"
+	PieCenterLineSpace = 18
+
+	PieSliceLabelStyle = "font-size:75%;"
+	PieLeaderStyle     = "fill:none;stroke:gray;stroke-width:1px;"
+	PieLeaderLength    = 12 // leader line length outside of the pie before it turns sideways
+	PieSliceLabelSpace = 12 // min vertical space between outside labels
+
+	PieOtherLabel = "Other"
+	PieOtherStyle = "fill:lightgray;stroke:gray;"
+
+	PieStartTop = -90 // StartAngle of the first slice at 12 o'clock
+
+	PieExplodeOffset  = 10
+	PieHighlightStyle = "stroke:black;stroke-width:2px;"
+)
+
+// Deprecated: slice colors come from Theme palette and font from Theme.
+const (
+	PieGstyle     = "font-family:Calibri; font-size:14"
+	PieFillStyle1 = "fill:red;stroke:gray;"
+	PieFillStyle2 = "fill:green;stroke:gray;"
+	PieFillStyle3 = "fill:navy;stroke:gray;"
+	PieFillStyle4 = "fill:orange;stroke:gray;"
+	PieFillStyle5 = "fill:gray;stroke:gray;"
+	PieFillStyle6 = "fill:white;stroke:gray;"
+	PieFillStyle7 = "fill:blue;stroke:gray;"
+	PieFillStyle8 = "fill:yellow;stroke:gray;"
+)
+
+// Deprecated: radius, gutters and legend offset that are not set are
+// computed from chart size, slice labels and legend.
+const (
+	PieGutterLeft    = 40
+	PieGutterTop     = 40
+	PieRadius        = 80
 	PieLegendXOffset = 40
 )
 
+// SliceLabel selects text drawn on pie slices.
+type SliceLabel int
+
+const (
+	SliceLabelNone SliceLabel = iota
+	SliceLabelValue
+	SliceLabelPercent
+	SliceLabelCustom // text produced by SliceLabelFunc
+)
+
+// pieItem is value drawn as pie slice, either one of PieValues or Other bucket.
+type pieItem struct {
+	index int // index in PieValues, -1 for Other
+	value float64
+	label string
+	style string
+}
+
+// pieSlice is single slice of the pie between start and end angles in degrees.
+type pieSlice struct {
+	pieItem
+	start, end float64
+	exploded   bool
+}
+
+// pieLabel is slice label placed outside of the pie.
+type pieLabel struct {
+	text   string
+	sx, sy float64 // slice center, moved from pie center for exploded slices
+	angle  float64 // slice middle angle
+	y      float64 // label y, moved to avoid neighbour labels
+	right  bool    // label is on the right side of the pie
+}
+
 type PieChart struct {
 	Svg           *svg.SVG
 	Width, Height int
-	PieValues     []int // chart bar values
-	Labels []string
-	Radius int
-	
+	PieValues     []float64 // chart slice values
+	Labels        []string
+	Radius        int // computed from chart size if not set
+
 	// optional fields below
-	FillStyles   []string	
-	
-	GutterLeft  int // left gutter for the chart, used to fit left labels
-	GutterTop   int // top gutter for the chart, used top label
-
-	// styles
-	Gstyle      string
-	PieStyle string
+	FillStyles []string
+
+	// donut related, InnerRadius turns pie into donut with hole of that radius
+	InnerRadius int
+	CenterLabel []string // lines of text drawn in the center, e.g. total or KPI and its caption
+	CenterTotal bool     // draw sum of PieValues as first center line
+
+	GutterLeft int // left gutter for the chart, used to fit left labels
+	GutterTop  int // top gutter for the chart, used top label
+
+	// titles, pie shrinks to make room for them
+	Title    string
+	Subtitle string
+	Caption  string // footnote at bottom left
+	Source   string // data source at bottom right
+
+	// styles, taken from Theme if not set
+	Classes        bool   // emit class names styled by StyleSheet instead of inline styles, DrawAt expects the document to include it
+	StyleSheetURL  string // with Classes, Draw imports this CSS instead of embedding StyleSheet of Theme
+	Theme          *Theme // DefaultTheme is used if not set
+	Gstyle         string
+	PieStyle       string
+	CenterStyle    string // style of first center line
+	CenterSubStyle string // style of other center lines
 	//LineStyle   string
 
+	// slice order and direction, slices are drawn clockwise in input order from 3 o'clock by default
+	Sort             bool    // sort slices by value, largest first
+	StartAngle       float64 // angle of the first slice in degrees, 0 is 3 o'clock, PieStartTop is 12 o'clock
+	CounterClockwise bool
+
+	// small slices are collapsed into single Other slice
+	OtherThreshold float64 // slices below this percent of the total go to Other
+	TopN           int     // only TopN largest slices are kept, rest go to Other
+	OtherLabel     string
+	OtherStyle     string
+
+	// exploded slices are pulled out from the center by ExplodeOffset and drawn with HighlightStyle
+	Exploded       []int // indexes of PieValues
+	ExplodeOffset  int
+	HighlightStyle string // added to fill style of exploded slices
+
+	// slice labels, placed at the slice center when they fit and outside with leader line when they don't
+	SliceLabels     SliceLabel
+	SliceLabelFunc  func(value, percent float64) string // used with SliceLabelCustom, percent is 0-100
+	SliceLabelStyle string
+	LeaderStyle     string
+	ValueFormat     Formatter // formats slice values and center total, shortest decimal form if not set
+	PercentFormat   Formatter // formats slice percents given as fractions, Percent(1) if not set
+
 	// legend related
-	Legend  string
+	Legend       string
+	LegendValues SliceLabel // value or percent added to legend labels, formatted like slice labels, none if not set
 	// legend offset
 	LegendXOffset int
 }
 
-// Draw produces chart on screen, main entry point.
-func (chart *PieChart) Draw() error {
-	canvas := chart.Svg
-	if chart.Svg == nil {
-		return fmt.Errorf("Missing pointer to svg.SVG in field Svg.")
-	}
-	if chart.Width < 10 || chart.Height < 10 {
-		return fmt.Errorf("Incorrect Width or Height value.")
+// Validate checks that all required chart fields are set.
+func (chart *PieChart) Validate() error {
+	if err := validateCanvas(chart.Svg, chart.Width, chart.Height); err != nil {
+		return err
 	}
 	if len(chart.PieValues) == 0 {
 		return fmt.Errorf("Missing PieValues for the chart.")
-	}	
+	}
 	if len(chart.PieValues) != len(chart.Labels) {
 		return fmt.Errorf("Number of PieValues does not match number of Labels.")
 	}
-	
-	if len(chart.FillStyles) == 0 {	// fill styles not set use defaults
-		chart.FillStyles = append(chart.FillStyles, PieFillStyle1)
-		chart.FillStyles = append(chart.FillStyles, PieFillStyle2)
-		chart.FillStyles = append(chart.FillStyles, PieFillStyle3)
-		chart.FillStyles = append(chart.FillStyles, PieFillStyle4)
-		chart.FillStyles = append(chart.FillStyles, PieFillStyle5)
-		chart.FillStyles = append(chart.FillStyles, PieFillStyle6)
-		chart.FillStyles = append(chart.FillStyles, PieFillStyle7)
-		chart.FillStyles = append(chart.FillStyles, PieFillStyle8)		
-	}
-	// default to sensible constants if value is not set
+	sum := 0.0
+	for i, val := range chart.PieValues {
+		if val < 0 || math.IsNaN(val) || math.IsInf(val, 0) {
+			return fmt.Errorf("PieValues item %d is negative or not a number: %v.", i, val)
+		}
+		sum += val
+	}
+	if sum == 0 {
+		return fmt.Errorf("Sum of PieValues must be greater than zero.")
+	}
+	if chart.InnerRadius < 0 || (chart.Radius > 0 && chart.InnerRadius >= chart.Radius) {
+		return fmt.Errorf("InnerRadius must be between zero and Radius.")
+	}
+	if chart.OtherThreshold < 0 || chart.OtherThreshold >= 100 || chart.TopN < 0 {
+		return fmt.Errorf("OtherThreshold must be between 0 and 100 and TopN can not be negative.")
+	}
+	for _, i := range chart.Exploded {
+		if i < 0 || i >= len(chart.PieValues) {
+			return fmt.Errorf("Exploded slice %d is out of PieValues range.", i)
+		}
+	}
+	if (chart.SliceLabels == SliceLabelCustom || chart.LegendValues == SliceLabelCustom) && chart.SliceLabelFunc == nil {
+		return fmt.Errorf("Missing SliceLabelFunc for SliceLabelCustom.")
+	}
+	return nil
+}
+
+// SetDefaults sets sensible constants for optional fields that are not set.
+func (chart *PieChart) SetDefaults() {
 	if chart.Gstyle == "" {
-		chart.Gstyle = PieGstyle
+		chart.Gstyle = stylesOf(chart.Theme, chart.Classes).Gstyle()
+	}
+	if chart.Classes {
+		// class names for styles without theme counterpart
+		if chart.OtherStyle == "" {
+			chart.OtherStyle = class("vichart-slice", "vichart-other")
+		}
+		if chart.HighlightStyle == "" {
+			chart.HighlightStyle = class("vichart-highlight")
+		}
+		if chart.LeaderStyle == "" {
+			chart.LeaderStyle = class("vichart-leader")
+		}
 	}
 	if chart.PieStyle == "" {
 		chart.PieStyle = PieStyle
 	}
-	if chart.Radius == 0 {
-		chart.Radius = PieRadius
+	if chart.CenterStyle == "" {
+		chart.CenterStyle = PieCenterStyle
 	}
-	
-	if chart.GutterTop == 0 {
-		chart.GutterTop = PieGutterTop
-	}	
-	if chart.GutterLeft == 0 {
-		chart.GutterLeft = PieGutterLeft
+	if chart.CenterSubStyle == "" {
+		chart.CenterSubStyle = PieCenterSubStyle
 	}
-	
-	if chart.LegendXOffset == 0 {
-		chart.LegendXOffset = PieLegendXOffset
+	if chart.OtherLabel == "" {
+		chart.OtherLabel = PieOtherLabel
 	}
-	
-	// convert values into degrees
-	sum := 0.0
-	for _, val := range chart.PieValues {
-		sum += float64(val)
+	if chart.OtherStyle == "" {
+		chart.OtherStyle = PieOtherStyle
 	}
-	angles := make([]float64, len(chart.PieValues), len(chart.PieValues))
-	for i, val := range chart.PieValues {
-		angles[i] = float64(val) * 360 / sum
+	if chart.ExplodeOffset == 0 {
+		chart.ExplodeOffset = PieExplodeOffset
+	}
+	if chart.HighlightStyle == "" {
+		chart.HighlightStyle = PieHighlightStyle
+	}
+	if chart.ValueFormat == nil {
+		chart.ValueFormat = formatValue
+	}
+	if chart.PercentFormat == nil {
+		chart.PercentFormat = Percent(1)
+	}
+	if chart.SliceLabelStyle == "" {
+		chart.SliceLabelStyle = PieSliceLabelStyle
+	}
+	if chart.LeaderStyle == "" {
+		chart.LeaderStyle = PieLeaderStyle
+	}
+}
+
+// layout returns radius, gutters and legend offset of the chart, those that
+// are not set are computed. Pie takes the space left by titles and legend,
+// with room around it for outside slice labels and exploded slices, and is
+// centered vertically.
+func (chart *PieChart) layout() chartLayout {
+	size := fontSize(chart.Theme)
+	area := chart.titles().area(chart.Width, chart.Height)
+	marginX, marginY := chart.margins(size)
+	l := chartLayout{gutterLeft: chart.GutterLeft, gutterTop: chart.GutterTop, legendX: chart.LegendXOffset,
+		radius: chart.Radius}
+	top := l.gutterTop
+	if l.gutterLeft == 0 {
+		l.gutterLeft = marginX + layoutPadding
+	}
+	if top == 0 {
+		top = marginY + layoutPadding
+	}
+	if l.radius == 0 {
+		legend := l.legendX
+		if legend == 0 {
+			legend = area.right - chart.legendWidth(size) - layoutPadding
+		}
+		width := legend - legendGap - marginX - l.gutterLeft - area.left
+		height := area.bottom - area.top - top - marginY - layoutPadding
+		l.radius = width / 2
+		if height < width {
+			l.radius = height / 2
+		}
+		// keep the ring of donut visible on small charts
+		if l.radius <= chart.InnerRadius {
+			l.radius = chart.InnerRadius + PieSliceLabelSpace
+		}
 	}
+	if l.gutterTop == 0 {
+		if free := (area.bottom - area.top - 2*l.radius) / 2; free > top {
+			top = free
+		}
+		l.gutterTop = top
+	}
+	if l.legendX == 0 {
+		l.legendX = area.left + l.gutterLeft + 2*l.radius + marginX + legendGap
+	}
+	return l
+}
+
+// margins returns space needed around the pie for exploded slices and outside
+// slice labels, on the sides and above and below the pie.
+func (chart *PieChart) margins(size float64) (x, y int) {
+	if len(chart.Exploded) > 0 {
+		x, y = chart.ExplodeOffset, chart.ExplodeOffset
+	}
+	if chart.SliceLabels == SliceLabelNone {
+		return x, y
+	}
+	// any label may end up outside, leader goes out and then sideways to the text
+	sum := chart.sum()
+	var texts []string
+	for _, item := range chart.items(sum) {
+		texts = append(texts, chart.sliceLabel(item, sum))
+	}
+	x += 2*PieLeaderLength + int(maxTextWidth(texts, size*labelScale)) + 3
+	y += PieLeaderLength + PieSliceLabelSpace/2
+	return x, y
+}
+
+// legendWidth returns width of the legend column, label text is centered
+// 50px right of the legend offset.
+func (chart *PieChart) legendWidth(size float64) int {
+	var labels []string
+	sum := chart.sum()
+	for _, item := range chart.items(sum) {
+		labels = append(labels, chart.legendLabel(item, sum))
+	}
+	width := 50 + int(maxTextWidth(labels, size*labelScale)/2)
+	if width < 30 {
+		return 30
+	}
+	return width
+}
+
+// titles returns chart titles, pie has no axis titles.
+func (chart *PieChart) titles() chartTitles {
+	return chartTitles{title: chart.Title, subtitle: chart.Subtitle,
+		caption: chart.Caption, source: chart.Source}
+}
+
+// Draw produces chart on screen, main entry point.
+func (chart *PieChart) Draw() error {
+	return drawChart(chart)
+}
+
+// DrawAt draws chart into its own viewport at x, y of existing SVG document
+// without starting or ending it.
+func (chart *PieChart) DrawAt(x, y int) error {
+	return drawChartAt(chart, x, y, false)
+}
+
+// SetCanvas points chart to canvas and sets chart size.
+func (chart *PieChart) SetCanvas(canvas *svg.SVG, width, height int) {
+	chart.Svg = canvas
+	chart.Width, chart.Height = width, height
+}
+
+// frame returns canvas of the chart and styles of its document.
+func (chart *PieChart) frame() chartFrame {
+	return chartFrame{chart.Svg, chart.Width, chart.Height, chart.Gstyle, chart.Theme, chart.Classes,
+		chart.StyleSheetURL}
+}
+
+// seriesCount returns number of series styled by style sheet, every slice
+// is series of its own.
+func (chart *PieChart) seriesCount() int {
+	return len(chart.PieValues)
+}
+
+// copy returns copy of chart for single draw, classes turns on class names.
+// Fill styles are copied too as SetDefaults fills them in.
+func (chart *PieChart) copy(classes bool) drawer {
+	c := *chart
+	c.Classes = c.Classes || classes
+	return &c
+}
+
+// draw renders chart body.
+func (chart *PieChart) draw() error {
+	canvas := chart.Svg
+
+	sum := chart.sum()
+	items := chart.items(sum)
+	slices := chart.slices(items, sum)
 
-	// start SVG
-	canvas.Start(chart.Width, chart.Height)
-	canvas.Gstyle(chart.Gstyle)
+	titles := chart.titles()
+	titles.draw(canvas, chart.Width, chart.Height)
+	area := titles.area(chart.Width, chart.Height)
 
+	l := chart.layout()
 	// cx, cy - center of the pie
-	cx := chart.GutterLeft + chart.Radius
-	cy := chart.GutterTop + chart.Radius
-		
-	var startAngle, endAngle float64
+	cx := area.left + l.gutterLeft + l.radius
+	cy := area.top + l.gutterTop + l.radius
+
 	// draw each slice in the loop
-	for i, val := range angles {
-		startAngle = endAngle
-		endAngle = startAngle + val
-		
-		radius := float64(chart.Radius)
-		bx := cx + int(radius * math.Cos(math.Pi * startAngle / 180))
-		by := cy + int(radius * math.Sin(math.Pi * startAngle / 180))
-		endx := cx + int(radius * math.Cos(math.Pi * endAngle / 180))
-		endy := cy + int(radius * math.Sin(math.Pi * endAngle / 180))
-		
-		path := fmt.Sprintf("M%d,%d  L%d,%d  A%d,%d 0 0,1 %d,%d z", cx, cy, bx, by, chart.Radius, chart.Radius, endx, endy)
-		canvas.Path(path, chart.FillStyles[i])
-	}		
+	for _, slice := range slices {
+		sx, sy := chart.sliceCenter(float64(cx), float64(cy), slice)
+		style := slice.style
+		if slice.exploded {
+			style = joinStyles(style, chart.HighlightStyle)
+		}
+		canvas.Path(chart.slicePath(sx, sy, float64(l.radius), slice.start, slice.end), style)
+	}
+	chart.drawCenter(cx, cy, sum)
+	if chart.SliceLabels != SliceLabelNone {
+		chart.drawSliceLabels(float64(cx), float64(cy), float64(l.radius), slices, sum)
+	}
 
 	// labels
-	labels := len(chart.Labels)
-	y := chart.GutterTop;
+	y := area.top + l.gutterTop
 	// display bottom line labels
-	for i := 0; i < labels; i++ {
+	canvas.Group(class("vichart-legend"))
+	for i, item := range items {
 		yoffset := int(float64(i) * 15)
-		canvas.Text(chart.LegendXOffset+50, y+yoffset, chart.Labels[i], "font-size:75%;text-anchor:middle;")
-		canvas.Rect(chart.LegendXOffset, y+yoffset-8, 30, 10, chart.FillStyles[i])
+		canvas.Text(l.legendX+50, y+yoffset, chart.legendLabel(item, sum), "font-size:75%;text-anchor:middle;")
+		canvas.Rect(l.legendX, y+yoffset-8, 30, 10, item.style)
 	}
-	
 	canvas.Gend()
-	canvas.End()
 	return nil
 }
 
+// sum returns sum of all PieValues.
+func (chart *PieChart) sum() float64 {
+	sum := 0.0
+	for _, val := range chart.PieValues {
+		sum += val
+	}
+	return sum
+}
+
+// items returns values in drawing order, sorted when asked to and with
+// small values collapsed into Other item. Styles stay with their values.
+func (chart *PieChart) items(sum float64) []pieItem {
+	var items []pieItem
+	styles := chart.fillStyles()
+	for i, val := range chart.PieValues {
+		items = append(items, pieItem{i, val, chart.Labels[i], styles[i]})
+	}
+
+	// top N are always largest values no matter of Sort
+	keep := make([]bool, len(items))
+	byValue := make([]int, len(items))
+	for i := range byValue {
+		byValue[i] = i
+	}
+	sort.SliceStable(byValue, func(i, j int) bool { return items[byValue[i]].value > items[byValue[j]].value })
+	for rank, i := range byValue {
+		keep[i] = (chart.TopN == 0 || rank < chart.TopN) && items[i].value*100/sum >= chart.OtherThreshold
+	}
+
+	other := pieItem{-1, 0, chart.OtherLabel, chart.OtherStyle}
+	var kept []pieItem
+	collapsed := 0
+	for i, item := range items {
+		if keep[i] {
+			kept = append(kept, item)
+			continue
+		}
+		other.value += item.value
+		collapsed++
+	}
+
+	if chart.Sort {
+		sort.SliceStable(kept, func(i, j int) bool { return kept[i].value > kept[j].value })
+	}
+	if collapsed > 0 {
+		kept = append(kept, other)
+	}
+	return kept
+}
+
+// slices converts items into slice angles going from StartAngle in chart direction,
+// start is always less than end, zero values are left out.
+func (chart *PieChart) slices(items []pieItem, sum float64) []pieSlice {
+	var slices []pieSlice
+	angle := chart.StartAngle
+	for _, item := range items {
+		sweep := item.value * 360 / sum
+		start, end := angle, angle+sweep
+		if chart.CounterClockwise {
+			start, end = angle-sweep, angle
+			angle -= sweep
+		} else {
+			angle += sweep
+		}
+		if item.value == 0 {
+			continue
+		}
+		slices = append(slices, pieSlice{item, start, end, chart.exploded(item.index)})
+	}
+	return slices
+}
+
+// exploded reports if slice of PieValues item i is pulled out.
+func (chart *PieChart) exploded(i int) bool {
+	for _, e := range chart.Exploded {
+		if e == i {
+			return true
+		}
+	}
+	return false
+}
+
+// sliceCenter returns center of slice arcs, exploded slices are moved away from
+// pie center along their middle angle. Slice that takes whole pie stays in place.
+func (chart *PieChart) sliceCenter(cx, cy float64, slice pieSlice) (float64, float64) {
+	if !slice.exploded || slice.end-slice.start >= 360 {
+		return cx, cy
+	}
+	return polar(cx, cy, float64(chart.ExplodeOffset), (slice.start+slice.end)/2)
+}
+
+// sliceLabel returns text of slice item label.
+func (chart *PieChart) sliceLabel(item pieItem, sum float64) string {
+	return chart.valueText(chart.SliceLabels, item, sum)
+}
+
+// legendLabel returns legend text of item with its value when LegendValues is set.
+func (chart *PieChart) legendLabel(item pieItem, sum float64) string {
+	if text := chart.valueText(chart.LegendValues, item, sum); text != "" {
+		return item.label + ": " + text
+	}
+	return item.label
+}
+
+// valueText returns value of item as text of kind, empty for SliceLabelNone.
+func (chart *PieChart) valueText(kind SliceLabel, item pieItem, sum float64) string {
+	val := item.value
+	percent := val * 100 / sum
+	switch kind {
+	case SliceLabelValue:
+		return chart.ValueFormat(val)
+	case SliceLabelPercent:
+		return chart.PercentFormat(percent / 100)
+	case SliceLabelCustom:
+		return chart.SliceLabelFunc(val, percent)
+	}
+	return ""
+}
+
+// drawSliceLabels draws labels at slice centers of pie with radius r when they
+// fit inside the slice, other labels go outside of the pie with leader lines.
+func (chart *PieChart) drawSliceLabels(cx, cy, r float64, slices []pieSlice, sum float64) {
+	canvas := chart.Svg
+	inner := float64(chart.InnerRadius)
+	// labels inside sit in the middle of the ring, or at 60% of radius for pie
+	mid := r * 0.6
+	if inner > 0 {
+		mid = (r + inner) / 2
+	}
+
+	var outside []pieLabel
+	for _, slice := range slices {
+		text := chart.sliceLabel(slice.pieItem, sum)
+		if text == "" {
+			continue
+		}
+		angle := (slice.start + slice.end) / 2
+		width := textWidth(text, fontSize(chart.Theme)*labelScale)
+		// chord at label radius has to be wider than text, ring has to be taller than text
+		sweep := math.Min(slice.end-slice.start, 180)
+		chord := 2 * mid * math.Sin(math.Pi*sweep/360)
+		sx, sy := chart.sliceCenter(cx, cy, slice)
+		if chord >= width+4 && r-inner >= PieSliceLabelSpace+4 {
+			x, y := polar(sx, sy, mid, angle)
+			canvas.Text(int(x), int(y), text, joinStyles(chart.SliceLabelStyle, "text-anchor:middle;baseline-shift:-33%"))
+			continue
+		}
+		lx, ly := polar(sx, sy, r+PieLeaderLength, angle)
+		outside = append(outside, pieLabel{text: text, sx: sx, sy: sy, angle: angle, y: ly, right: lx >= cx})
+	}
+
+	// outside labels keep clear of exploded slices too
+	outer := r + PieLeaderLength
+	if len(chart.Exploded) > 0 {
+		outer += float64(chart.ExplodeOffset)
+	}
+	top, bottom := cy-outer, cy+outer
+	spreadPieLabels(outside, true, top, bottom)
+	spreadPieLabels(outside, false, top, bottom)
+	for _, label := range outside {
+		// leader goes out of the pie, turns to label height and ends next to label text
+		ex, ey := polar(label.sx, label.sy, r, label.angle)
+		elbowX, _ := polar(label.sx, label.sy, r+PieLeaderLength, label.angle)
+		textX, anchor := cx+outer+PieLeaderLength, "text-anchor:start;"
+		if !label.right {
+			textX, anchor = cx-outer-PieLeaderLength, "text-anchor:end;"
+		}
+		endX := textX - 3
+		if !label.right {
+			endX = textX + 3
+		}
+		canvas.Polyline([]int{int(ex), int(elbowX), int(endX)},
+			[]int{int(ey), int(label.y), int(label.y)}, chart.LeaderStyle)
+		canvas.Text(int(textX), int(label.y), label.text, joinStyles(chart.SliceLabelStyle, anchor+"baseline-shift:-33%"))
+	}
+}
 
+// spreadPieLabels moves outside labels on one side of the pie apart, so there is
+// at least PieSliceLabelSpace between neighbours. Labels pushed below bottom are
+// moved back up as long as they stay below top.
+func spreadPieLabels(labels []pieLabel, right bool, top, bottom float64) {
+	var side []*pieLabel
+	for i := range labels {
+		if labels[i].right == right {
+			side = append(side, &labels[i])
+		}
+	}
+	sort.Slice(side, func(i, j int) bool { return side[i].y < side[j].y })
+	for i := 1; i < len(side); i++ {
+		if side[i].y-side[i-1].y < PieSliceLabelSpace {
+			side[i].y = side[i-1].y + PieSliceLabelSpace
+		}
+	}
+	for i := len(side) - 1; i >= 0; i-- {
+		limit := bottom
+		if i < len(side)-1 {
+			limit = side[i+1].y - PieSliceLabelSpace
+		}
+		if side[i].y > limit {
+			side[i].y = math.Max(limit, top)
+		}
+	}
+}
+
+// slicePath returns SVG path of slice of pie with radius r between start and
+// end angles in degrees. Wedge from the center is returned for pie and
+// annular sector for donut.
+func (chart *PieChart) slicePath(cx, cy, r, start, end float64) string {
+	inner := float64(chart.InnerRadius)
+	if end-start >= 360 {
+		// whole pie is drawn as two half arcs, arc can not start and end at the same point
+		path := fmt.Sprintf("M%.2f,%.2f A%.2f,%.2f 0 1,1 %.2f,%.2f A%.2f,%.2f 0 1,1 %.2f,%.2f z",
+			cx+r, cy, r, r, cx-r, cy, r, r, cx+r, cy)
+		if inner > 0 {
+			// hole goes the other way so it stays empty
+			path += fmt.Sprintf(" M%.2f,%.2f A%.2f,%.2f 0 1,0 %.2f,%.2f A%.2f,%.2f 0 1,0 %.2f,%.2f z",
+				cx+inner, cy, inner, inner, cx-inner, cy, inner, inner, cx+inner, cy)
+		}
+		return path
+	}
+	largeArc := 0
+	if end-start > 180 {
+		largeArc = 1
+	}
+	x1, y1 := polar(cx, cy, r, start)
+	x2, y2 := polar(cx, cy, r, end)
+	if inner == 0 {
+		return fmt.Sprintf("M%.2f,%.2f L%.2f,%.2f A%.2f,%.2f 0 %d,1 %.2f,%.2f z",
+			cx, cy, x1, y1, r, r, largeArc, x2, y2)
+	}
+	x3, y3 := polar(cx, cy, inner, end)
+	x4, y4 := polar(cx, cy, inner, start)
+	return fmt.Sprintf("M%.2f,%.2f A%.2f,%.2f 0 %d,1 %.2f,%.2f L%.2f,%.2f A%.2f,%.2f 0 %d,0 %.2f,%.2f z",
+		x1, y1, r, r, largeArc, x2, y2, x3, y3, inner, inner, largeArc, x4, y4)
+}
+
+// drawCenter draws center label lines of donut around cx, cy.
+func (chart *PieChart) drawCenter(cx, cy int, sum float64) {
+	lines := chart.CenterLabel
+	if chart.CenterTotal {
+		lines = append([]string{chart.ValueFormat(sum)}, lines...)
+	}
+	if len(lines) == 0 {
+		return
+	}
+	canvas := chart.Svg
+	// block of lines is vertically centered, first line is the largest one
+	y := cy - (len(lines)-1)*PieCenterLineSpace/2
+	for i, line := range lines {
+		style := chart.CenterSubStyle
+		if i == 0 {
+			style = chart.CenterStyle
+		}
+		canvas.Text(cx, y+i*PieCenterLineSpace, line, joinStyles(style, "baseline-shift:-33%"))
+	}
+}
+
+// polar returns point at angle in degrees and distance r from cx, cy.
+func polar(cx, cy, r, angle float64) (float64, float64) {
+	return cx + r*math.Cos(math.Pi*angle/180), cy + r*math.Sin(math.Pi*angle/180)
+}
+
+// fillStyles returns style of every slice. FillStyles are cycled when there
+// are more slices than styles. Without them slices get class names or theme
+// palette colors, followed by generated distinct colors when there are more
+// slices than palette colors. Styles are computed for every draw, so they
+// follow the number of values.
+func (chart *PieChart) fillStyles() []string {
+	theme := themeOf(chart.Theme)
+	colors := len(theme.colors())
+	var generated []string
+	if len(chart.FillStyles) == 0 && !chart.Classes && len(chart.PieValues) > colors {
+		generated = palette.Generate(len(chart.PieValues)).FillStyles(theme.Stroke)
+	}
+	styles := make([]string, len(chart.PieValues))
+	for i := range styles {
+		switch {
+		case len(chart.FillStyles) > 0:
+			styles[i] = chart.FillStyles[i%len(chart.FillStyles)]
+		case chart.Classes:
+			styles[i] = class("vichart-slice", seriesClass(i))
+		case i < colors:
+			styles[i] = theme.FillStyle(i)
+		default:
+			styles[i] = generated[i]
+		}
+	}
+	return styles
+}
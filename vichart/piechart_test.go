@@ -0,0 +1,118 @@
+// ViChart library for Go
+// Author: Tad Vizbaras 
+// License: http://github.com/tadvi/vichart/blob/master/LICENSE 
+//
+package vichart
+
+import (
+	"bytes"
+	"fmt"
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/ajstarks/svgo"
+)
+
+// pieChart returns pie chart with n equal slices drawing into buf.
+func pieChart(buf *bytes.Buffer, n int) *PieChart {
+	chart := &PieChart{Svg: svg.New(buf), Width: 400, Height: 300}
+	setSlices(chart, n)
+	return chart
+}
+
+// setSlices sets n equal values with labels on chart.
+func setSlices(chart *PieChart, n int) {
+	chart.PieValues, chart.Labels = nil, nil
+	for i := 0; i < n; i++ {
+		chart.PieValues = append(chart.PieValues, 1)
+		chart.Labels = append(chart.Labels, fmt.Sprint("slice ", i))
+	}
+}
+
+// sliceFills returns styles of drawn slices.
+func sliceFills(doc []element) []string {
+	var fills []string
+	for _, e := range named(doc, "path") {
+		fills = append(fills, e.attrs["style"])
+	}
+	return fills
+}
+
+func TestPieColorsFollowValues(t *testing.T) {
+	var buf bytes.Buffer
+	chart := pieChart(&buf, 3)
+	render(t, chart, &buf)
+	setSlices(chart, 14) // more slices than palette colors after first draw
+	fills := sliceFills(render(t, chart, &buf))
+	if len(fills) != 14 {
+		t.Fatalf("draws %d slices, want 14", len(fills))
+	}
+	seen := map[string]bool{}
+	for i, fill := range fills {
+		if seen[fill] {
+			t.Errorf("slice %d repeats fill %q", i, fill)
+		}
+		seen[fill] = true
+	}
+	if len(chart.FillStyles) != 0 {
+		t.Errorf("draw set %d fill styles", len(chart.FillStyles))
+	}
+}
+
+// arcRe matches elliptical arc of slice path with its large arc flag.
+var arcRe = regexp.MustCompile(`A[0-9.]+,[0-9.]+ 0 ([01]),[01]`)
+
+// largeArcs returns large arc flags of arcs in slice path d.
+func largeArcs(d string) string {
+	var flags []string
+	for _, m := range arcRe.FindAllStringSubmatch(d, -1) {
+		flags = append(flags, m[1])
+	}
+	return strings.Join(flags, " ")
+}
+
+func TestPieLargeArc(t *testing.T) {
+	var buf bytes.Buffer
+	tests := []struct {
+		name   string
+		values []float64
+		inner  int
+		want   []string // large arc flags of every slice
+	}{
+		{"pie", []float64{3, 1}, 0, []string{"1", "0"}},
+		{"half", []float64{1, 1}, 0, []string{"0", "0"}},
+		{"donut", []float64{1, 3}, 40, []string{"0 0", "1 1"}},
+		{"whole pie", []float64{5}, 0, []string{"1 1"}},
+		{"whole donut", []float64{5}, 40, []string{"1 1 1 1"}},
+	}
+	for _, tt := range tests {
+		chart := pieChart(&buf, len(tt.values))
+		chart.PieValues, chart.InnerRadius = tt.values, tt.inner
+		doc := render(t, chart, &buf)
+		if strings.Contains(buf.String(), "NaN") {
+			t.Errorf("%s: document has NaN", tt.name)
+		}
+		slices := named(doc, "path")
+		if len(slices) != len(tt.want) {
+			t.Errorf("%s: draws %d slices, want %d", tt.name, len(slices), len(tt.want))
+			continue
+		}
+		for i, slice := range slices {
+			if got := largeArcs(slice.attrs["d"]); got != tt.want[i] {
+				t.Errorf("%s: slice %d has large arc flags %q, want %q in %q", tt.name, i, got, tt.want[i], slice.attrs["d"])
+			}
+		}
+	}
+}
+
+func TestPieFillStylesCycle(t *testing.T) {
+	var buf bytes.Buffer
+	chart := pieChart(&buf, 5)
+	chart.FillStyles = []string{"fill:red;", "fill:blue;"}
+	fills := sliceFills(render(t, chart, &buf))
+	want := []string{"fill:red;", "fill:blue;", "fill:red;", "fill:blue;", "fill:red;"}
+	if strings.Join(fills, " ") != strings.Join(want, " ") {
+		t.Errorf("slices have fills %q, want %q", fills, want)
+	}
+}
@@ -0,0 +1,386 @@
+// ViChart library for Go
+// Author: Tad Vizbaras 
+// License: http://github.com/tadvi/vichart/blob/master/LICENSE 
+//
+package vichart
+
+import (
+	"fmt"
+	"math"
+)
+
+const (
+	ScaleTicks = 5 // approximate number of ticks generated for axis
+
+	maxTicks = 1000 // upper bound of generated ticks, guards against tiny steps
+)
+
+// ScaleType selects how values are mapped along value axis.
+type ScaleType int
+
+const (
+	ScaleLinear ScaleType = iota
+	ScaleLog10            // logarithmic with ticks at powers of 10, values must be positive
+	ScaleLog2             // logarithmic with ticks at powers of 2, values must be positive
+	ScaleSymlog           // linear around zero and logarithmic further out, for values of both signs
+	ScaleSqrt             // square root, sign is kept for negative values
+)
+
+// log reports if scale type is logarithmic and can not show zero and negative values.
+func (t ScaleType) log() bool {
+	return t == ScaleLog10 || t == ScaleLog2
+}
+
+// transform converts value into space where scale of type t is linear.
+func (t ScaleType) transform(val float64) float64 {
+	switch t {
+	case ScaleLog10:
+		return math.Log10(val)
+	case ScaleLog2:
+		return math.Log2(val)
+	case ScaleSymlog:
+		return math.Copysign(math.Log10(1+math.Abs(val)), val)
+	case ScaleSqrt:
+		return math.Copysign(math.Sqrt(math.Abs(val)), val)
+	}
+	return val
+}
+
+// base returns base of logarithmic scale type.
+func (t ScaleType) base() float64 {
+	if t == ScaleLog2 {
+		return 2
+	}
+	return 10
+}
+
+// Scale maps values from domain [Min, Max] into pixel range [From, To].
+// From is pixel position of Min and To is pixel position of Max, so range
+// can be inverted as it is for vertical axis. Scale is linear unless Type
+// is set, values are transformed by Type first and then mapped linearly.
+type Scale struct {
+	Min, Max float64 // domain
+	From, To float64 // pixel range
+	Type     ScaleType
+}
+
+// NewScale creates scale of type kind for values between min and max. When
+// nice is set domain is extended to nice round numbers so first and last ticks
+// sit on the ends, logarithmic domain is extended to powers of its base that
+// surround positive values. Logarithmic scale shows only positive values,
+// min that is not positive is moved to power of base below max.
+func NewScale(kind ScaleType, min, max, from, to float64, nice bool) Scale {
+	if kind.log() {
+		if nice {
+			return axisScale(kind, 0, 0, []float64{min, max}, from, to)
+		}
+		if min <= 0 {
+			return axisScale(kind, 0, max, []float64{min, max}, from, to)
+		}
+		if min == max {
+			max = min * kind.base()
+		}
+		return Scale{Min: min, Max: max, From: from, To: to, Type: kind}
+	}
+	if min == max {
+		max = min + 1
+	}
+	if nice {
+		min, max, _ = niceDomain(min, max, ScaleTicks)
+	}
+	return Scale{Min: min, Max: max, From: from, To: to, Type: kind}
+}
+
+// Map converts domain value into pixel position.
+func (s Scale) Map(val float64) float64 {
+	if s.Max == s.Min {
+		return s.From
+	}
+	if s.Type != ScaleLinear {
+		min, max := s.Type.transform(s.Min), s.Type.transform(s.Max)
+		return s.From + (s.Type.transform(val)-min)/(max-min)*(s.To-s.From)
+	}
+	return s.From + (val-s.Min)/(s.Max-s.Min)*(s.To-s.From)
+}
+
+// Base returns pixel position bars grow from, position of zero or of domain
+// minimum on logarithmic scale that has no zero.
+func (s Scale) Base() float64 {
+	if s.Type.log() {
+		return s.From
+	}
+	return s.Map(0)
+}
+
+// barEnd returns pixel position where bar of val ends, bars of values below
+// domain minimum of logarithmic scale end at its base.
+func (s Scale) barEnd(val float64) float64 {
+	if s.Type.log() && val <= s.Min {
+		return s.From
+	}
+	return s.Map(val)
+}
+
+// Ticks returns nice round tick values within domain, approximately count of
+// them. Logarithmic scales have ticks at powers of their base, symlog scale
+// at zero and powers of 10 of both signs.
+func (s Scale) Ticks(count int) []float64 {
+	switch {
+	case s.Type.log():
+		if ticks := s.powerTicks(s.Min, s.Max, count); len(ticks) > 1 {
+			return ticks
+		}
+	case s.Type == ScaleSymlog:
+		var ticks []float64
+		if s.Min < -1 {
+			negative := s.powerTicks(1, -s.Min, count/2)
+			for i := len(negative) - 1; i >= 0; i-- {
+				ticks = append(ticks, -negative[i])
+			}
+		}
+		if s.Min <= 0 && s.Max >= 0 {
+			ticks = append(ticks, 0)
+		}
+		if s.Max > 1 {
+			ticks = append(ticks, s.powerTicks(1, s.Max, count/2+1)...)
+		}
+		if len(ticks) > 1 {
+			return ticks
+		}
+	}
+	return s.linearTicks(count)
+}
+
+// powerTicks returns powers of scale base between min and max, when there are
+// many of them only every n-th power is returned.
+func (s Scale) powerTicks(min, max float64, count int) []float64 {
+	base := s.Type.base()
+	// small epsilon keeps powers at domain ends despite float rounding
+	lo := math.Ceil(math.Log(min)/math.Log(base) - 1e-9)
+	hi := math.Floor(math.Log(max)/math.Log(base) + 1e-9)
+	if !finite(lo, hi) {
+		return nil
+	}
+	step := 1.0
+	if count > 0 && hi-lo > float64(count*2) {
+		step = math.Ceil((hi - lo) / float64(count*2))
+	}
+	var ticks []float64
+	for exp := lo; exp <= hi && len(ticks) < maxTicks; exp += step {
+		ticks = append(ticks, math.Pow(base, exp))
+	}
+	return ticks
+}
+
+// linearTicks returns nice round tick values within domain spaced evenly.
+func (s Scale) linearTicks(count int) []float64 {
+	_, _, step := niceDomain(s.Min, s.Max, count)
+	if step == 0 || !finite(step, s.Min, s.Max) {
+		return []float64{s.Min}
+	}
+	var ticks []float64
+	first := math.Ceil(s.Min/step) * step
+	// small epsilon avoids losing last tick to float rounding
+	for i := 0; i < maxTicks; i++ {
+		tick := first + float64(i)*step
+		if tick > s.Max+step*1e-9 {
+			break
+		}
+		if math.Abs(tick) < step*1e-9 {
+			tick = 0 // avoid -0 labels
+		}
+		ticks = append(ticks, tick)
+	}
+	return ticks
+}
+
+// TickLabels formats ticks with format, or with DefaultLocale using precision
+// needed by tick step when format is nil. Ticks of logarithmic and symlog
+// scales are not evenly spaced and are formatted in shortest form.
+func (s Scale) TickLabels(ticks []float64, format Formatter) []string {
+	if format == nil && (s.Type.log() || s.Type == ScaleSymlog) {
+		format = formatValue
+	}
+	if format == nil {
+		decimals := 0
+		if len(ticks) > 1 {
+			step := math.Abs(ticks[1] - ticks[0])
+			if step > 0 && step < 1 {
+				decimals = int(math.Ceil(-math.Log10(step)))
+			}
+		}
+		format = DefaultLocale.Fixed(decimals)
+	}
+	labels := make([]string, len(ticks))
+	for i, tick := range ticks {
+		labels[i] = format(tick)
+	}
+	return labels
+}
+
+// niceDomain extends min and max outwards to multiples of nice step.
+func niceDomain(min, max float64, count int) (niceMin, niceMax, step float64) {
+	if count < 2 {
+		count = 2
+	}
+	span := niceNum(max-min, false)
+	step = niceNum(span/float64(count-1), true)
+	if step == 0 {
+		return min, max, 0
+	}
+	niceMin = math.Floor(min/step) * step
+	niceMax = math.Ceil(max/step) * step
+	return niceMin, niceMax, step
+}
+
+// niceNum finds "nice" number (1, 2, 5 times power of 10) approximately equal to x.
+// Number is rounded when round is set, otherwise ceiling is taken.
+func niceNum(x float64, round bool) float64 {
+	if x <= 0 {
+		return 0
+	}
+	exp := math.Floor(math.Log10(x))
+	f := x / math.Pow(10, exp)
+	var nf float64
+	if round {
+		switch {
+		case f < 1.5:
+			nf = 1
+		case f < 3:
+			nf = 2
+		case f < 7:
+			nf = 5
+		default:
+			nf = 10
+		}
+	} else {
+		switch {
+		case f <= 1:
+			nf = 1
+		case f <= 2:
+			nf = 2
+		case f <= 5:
+			nf = 5
+		default:
+			nf = 10
+		}
+	}
+	return nf * math.Pow(10, exp)
+}
+
+// valueScale creates scale for bar and line values. Min and max set by caller
+// are used as is, ends that are not set are computed from data range and
+// rounded to nice numbers. Zero is always part of the domain so bars have baseline.
+func valueScale(min, max, dataMin, dataMax, from, to float64) Scale {
+	lo, hi := math.Min(min, dataMin), math.Max(max, dataMax)
+	if min != 0 {
+		lo = min
+	}
+	if max != 0 {
+		hi = max
+	}
+	if lo == hi {
+		hi = lo + 1
+	}
+	niceMin, niceMax, _ := niceDomain(lo, hi, ScaleTicks)
+	if min == 0 {
+		lo = niceMin
+	}
+	if max == 0 {
+		hi = niceMax
+	}
+	return NewScale(ScaleLinear, lo, hi, from, to, false)
+}
+
+// axisScale creates scale of type for values between from and to. Linear,
+// symlog and sqrt scales have domain of valueScale, logarithmic scales have
+// domain extended to powers of their base that surround positive values.
+func axisScale(kind ScaleType, min, max float64, values []float64, from, to float64) Scale {
+	if !kind.log() {
+		dataMin, dataMax := valueRange(values)
+		scale := valueScale(min, max, dataMin, dataMax, from, to)
+		scale.Type = kind
+		return scale
+	}
+	lo, hi := math.Inf(1), math.Inf(-1)
+	for _, val := range values {
+		if val > 0 {
+			lo, hi = math.Min(lo, val), math.Max(hi, val)
+		}
+	}
+	if math.IsInf(lo, 0) {
+		lo, hi = 1, 1
+	}
+	base := kind.base()
+	if min <= 0 {
+		min = math.Pow(base, math.Floor(math.Log(lo)/math.Log(base)+1e-9))
+	}
+	if max <= 0 {
+		max = math.Pow(base, math.Ceil(math.Log(hi)/math.Log(base)-1e-9))
+	}
+	if max <= min {
+		max = min * base
+	}
+	return Scale{Min: min, Max: max, From: from, To: to, Type: kind}
+}
+
+// validateScale checks that values named name and domain minimum min set by
+// caller can be shown on scale of type kind.
+func validateScale(kind ScaleType, name string, min float64, values []float64) error {
+	if !kind.log() {
+		return nil
+	}
+	if min < 0 {
+		return fmt.Errorf("Minimum of logarithmic scale must be positive.")
+	}
+	for _, val := range values {
+		if val <= 0 || math.IsNaN(val) {
+			return fmt.Errorf("%s must be positive for logarithmic scale.", name)
+		}
+	}
+	return nil
+}
+
+// validateDomain checks that scale of values named name has finite domain with
+// minimum below maximum, so values are drawn inside the plot.
+func validateDomain(name string, scale Scale) error {
+	if !finite(scale.Min, scale.Max, scale.Max-scale.Min) {
+		return fmt.Errorf("Range of %s is too large.", name)
+	}
+	if scale.Min >= scale.Max {
+		return fmt.Errorf("Minimum of %s must be less than maximum.", name)
+	}
+	return nil
+}
+
+// valueRange returns smallest and largest value, range always includes zero.
+func valueRange(values []float64) (min, max float64) {
+	for _, val := range values {
+		min = math.Min(min, val)
+		max = math.Max(max, val)
+	}
+	return min, max
+}
+
+// axisTicks returns pixel positions and labels for axis markers. Labels supplied
+// by caller are spread evenly along the axis, otherwise they are generated
+// from scale ticks with format so they always match drawn values.
+func axisTicks(scale Scale, labels []string, format Formatter) ([]float64, []string) {
+	if len(labels) > 0 {
+		pos := make([]float64, len(labels))
+		for i := range labels {
+			if len(labels) == 1 {
+				pos[i] = scale.From
+				continue
+			}
+			pos[i] = scale.From + float64(i)*(scale.To-scale.From)/float64(len(labels)-1)
+		}
+		return pos, labels
+	}
+	ticks := scale.Ticks(ScaleTicks)
+	pos := make([]float64, len(ticks))
+	for i, tick := range ticks {
+		pos[i] = scale.Map(tick)
+	}
+	return pos, scale.TickLabels(ticks, format)
+}
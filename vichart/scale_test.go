@@ -0,0 +1,191 @@
+// ViChart library for Go
+// Author: Tad Vizbaras 
+// License: http://github.com/tadvi/vichart/blob/master/LICENSE 
+//
+package vichart
+
+import (
+	"bytes"
+	"math"
+	"testing"
+
+	"github.com/ajstarks/svgo"
+)
+
+// equalFloats reports if a and b hold the same values up to float rounding.
+func equalFloats(a, b []float64) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if math.Abs(a[i]-b[i]) > 1e-9*math.Max(1, math.Abs(b[i])) {
+			return false
+		}
+	}
+	return true
+}
+
+func TestNiceNum(t *testing.T) {
+	tests := []struct {
+		x     float64
+		round bool
+		want  float64
+	}{
+		{0, false, 0},
+		{-3, true, 0},
+		{1, false, 1},
+		{1.2, false, 2},
+		{4.5, false, 5},
+		{7, false, 10},
+		{0.034, false, 0.05},
+		{1.4, true, 1},
+		{2.5, true, 2},
+		{6, true, 5},
+		{8, true, 10},
+		{1234, true, 1000},
+	}
+	for _, tt := range tests {
+		if got := niceNum(tt.x, tt.round); !equalFloats([]float64{got}, []float64{tt.want}) {
+			t.Errorf("niceNum(%v, %v) = %v, want %v", tt.x, tt.round, got, tt.want)
+		}
+	}
+	if got := niceNum(math.NaN(), true); !math.IsNaN(got) {
+		t.Errorf("niceNum(NaN, true) = %v, want NaN", got)
+	}
+}
+
+func TestNiceDomain(t *testing.T) {
+	tests := []struct {
+		min, max float64
+		count    int
+		want     []float64 // nice min, nice max and step
+	}{
+		{0, 93, 5, []float64{0, 100, 20}},
+		{-12, 47, 5, []float64{-20, 60, 20}},
+		{0, 0.7, 5, []float64{0, 0.8, 0.2}},
+		{0, 1200, 3, []float64{0, 2000, 1000}},
+		{0, 10, 1, []float64{0, 10, 10}},
+		{5, 5, 5, []float64{5, 5, 0}},
+	}
+	for _, tt := range tests {
+		min, max, step := niceDomain(tt.min, tt.max, tt.count)
+		if got := []float64{min, max, step}; !equalFloats(got, tt.want) {
+			t.Errorf("niceDomain(%v, %v, %d) = %v, want %v", tt.min, tt.max, tt.count, got, tt.want)
+		}
+	}
+}
+
+func TestScaleTicks(t *testing.T) {
+	tests := []struct {
+		name  string
+		scale Scale
+		count int
+		want  []float64
+	}{
+		{"linear", Scale{Min: 0, Max: 100}, 5, []float64{0, 20, 40, 60, 80, 100}},
+		{"linear negative", Scale{Min: -20, Max: 60}, 5, []float64{-20, 0, 20, 40, 60}},
+		{"linear fraction", Scale{Min: 0, Max: 0.8}, 5, []float64{0, 0.2, 0.4, 0.6, 0.8}},
+		{"linear empty domain", Scale{Min: 5, Max: 5}, 5, []float64{5}},
+		{"log10", Scale{Min: 1, Max: 1000, Type: ScaleLog10}, 5, []float64{1, 10, 100, 1000}},
+		{"log10 many powers", Scale{Min: 1, Max: 1e12, Type: ScaleLog10}, 5,
+			[]float64{1, 1e2, 1e4, 1e6, 1e8, 1e10, 1e12}},
+		{"log2", Scale{Min: 1, Max: 16, Type: ScaleLog2}, 5, []float64{1, 2, 4, 8, 16}},
+		{"log2 without powers", Scale{Min: 5, Max: 7, Type: ScaleLog2}, 5, []float64{5, 5.5, 6, 6.5, 7}},
+		{"symlog", Scale{Min: -100, Max: 1000, Type: ScaleSymlog}, 5,
+			[]float64{-100, -10, -1, 0, 1, 10, 100, 1000}},
+		{"symlog positive", Scale{Min: 0, Max: 100, Type: ScaleSymlog}, 5, []float64{0, 1, 10, 100}},
+		{"sqrt", Scale{Min: 0, Max: 100, Type: ScaleSqrt}, 5, []float64{0, 20, 40, 60, 80, 100}},
+	}
+	for _, tt := range tests {
+		if got := tt.scale.Ticks(tt.count); !equalFloats(got, tt.want) {
+			t.Errorf("%s: Ticks(%d) = %v, want %v", tt.name, tt.count, got, tt.want)
+		}
+	}
+}
+
+func TestScaleTicksNotFinite(t *testing.T) {
+	nan, inf := math.NaN(), math.Inf(1)
+	tests := []struct {
+		name  string
+		scale Scale
+	}{
+		{"NaN min", Scale{Min: nan, Max: 1}},
+		{"NaN max", Scale{Min: 0, Max: nan}},
+		{"infinite max", Scale{Min: 0, Max: inf}},
+		{"infinite domain", Scale{Min: -inf, Max: inf}},
+		{"log10 NaN", Scale{Min: nan, Max: 10, Type: ScaleLog10}},
+		{"log10 infinite", Scale{Min: 1, Max: inf, Type: ScaleLog10}},
+		{"symlog NaN", Scale{Min: -10, Max: nan, Type: ScaleSymlog}},
+		{"tiny step", Scale{Min: 1, Max: 1 + 1e-15}},
+	}
+	for _, tt := range tests {
+		if got := tt.scale.Ticks(ScaleTicks); len(got) > maxTicks {
+			t.Errorf("%s: Ticks returned %d ticks, want at most %d", tt.name, len(got), maxTicks)
+		}
+	}
+}
+
+func TestNewScale(t *testing.T) {
+	tests := []struct {
+		name     string
+		kind     ScaleType
+		min, max float64
+		nice     bool
+		want     []float64 // domain min and max
+	}{
+		{"linear", ScaleLinear, 3, 93, false, []float64{3, 93}},
+		{"linear nice", ScaleLinear, 3, 93, true, []float64{0, 100}},
+		{"linear empty domain", ScaleLinear, 4, 4, false, []float64{4, 5}},
+		{"sqrt nice", ScaleSqrt, 3, 93, true, []float64{0, 100}},
+		{"log10 nice", ScaleLog10, 3, 700, true, []float64{1, 1000}},
+		{"log2 nice", ScaleLog2, 3, 20, true, []float64{2, 32}},
+		{"log10 nice non-positive", ScaleLog10, -5, 0, true, []float64{1, 10}},
+		{"log2 empty domain", ScaleLog2, 3, 3, false, []float64{3, 6}},
+		{"log10 zero min", ScaleLog10, 0, 700, false, []float64{100, 700}},
+		{"log10 non-positive", ScaleLog10, -5, -1, false, []float64{1, 10}},
+	}
+	for _, tt := range tests {
+		s := NewScale(tt.kind, tt.min, tt.max, 0, 100, tt.nice)
+		if got := []float64{s.Min, s.Max}; !equalFloats(got, tt.want) || s.Type != tt.kind {
+			t.Errorf("%s: domain %v of type %v, want %v of type %v", tt.name, got, s.Type, tt.want, tt.kind)
+		}
+		if pos := s.Map(s.Max); !finite(pos) {
+			t.Errorf("%s: Map(%v) = %v, want pixel position", tt.name, s.Max, pos)
+		}
+	}
+}
+
+func TestValidateDomain(t *testing.T) {
+	canvas := svg.New(&bytes.Buffer{})
+	line := func(values, x []float64, min float64) *LineChart {
+		return &LineChart{Svg: canvas, Width: 400, Height: 300, MinValue: min,
+			Series: []LineSeries{{Values: values, X: x}}}
+	}
+	tests := []struct {
+		name  string
+		chart Chart
+		valid bool
+	}{
+		{"bars", &VBarChart{Svg: canvas, Width: 400, Height: 300, BarValues: []float64{1, 5}}, true},
+		{"bars min above max", &VBarChart{Svg: canvas, Width: 400, Height: 300, BarValues: []float64{1, 5},
+			MinBarValue: 10}, false},
+		{"bars too wide", &VBarChart{Svg: canvas, Width: 400, Height: 300, BarValues: []float64{1e308, -1e308}}, false},
+		{"bar line min above max", &VBarChart{Svg: canvas, Width: 400, Height: 300, BarValues: []float64{1, 5},
+			LineValues: []float64{1, 2}, MinLineValue: 3}, false},
+		{"bar line not drawn", &VBarChart{Svg: canvas, Width: 400, Height: 300, BarValues: []float64{1, 5},
+			MinLineValue: 3}, true},
+		{"multi bars min above max", &VBMultiChart{Svg: canvas, Width: 400, Height: 300,
+			BarValues: []VBMultiChartItem{{1, 2}}, MaxBarValue: -1}, false},
+		{"horizontal bars min above max", &HBarChart{Svg: canvas, Width: 400, Height: 300,
+			BarValues: []float64{1, 2}, LabelsY: []string{"a", "b"}, MinValue: 2, MaxValue: 1}, false},
+		{"line", line([]float64{1, 2}, nil, 0), true},
+		{"line single point", line([]float64{1}, nil, 0), true},
+		{"line min above max", line([]float64{1, 2}, nil, 5), false},
+		{"line X too wide", line([]float64{1, 2}, []float64{-1e308, 1e308}, 0), false},
+	}
+	for _, tt := range tests {
+		if err := tt.chart.Validate(); (err == nil) != tt.valid {
+			t.Errorf("%s: Validate() = %v, want valid %v", tt.name, err, tt.valid)
+		}
+	}
+}
@@ -0,0 +1,181 @@
+// ViChart library for Go
+// Author: Tad Vizbaras 
+// License: http://github.com/tadvi/vichart/blob/master/LICENSE 
+//
+package vichart
+
+import (
+	"fmt"
+	"vichart/palette"
+)
+
+// Theme is look shared by all charts: font, colors of text, axis and grid
+// lines, background and palette of series colors. Charts take their default
+// styles from the theme, style fields set on chart take precedence. Font and
+// palette that are not set are taken from DefaultTheme.
+type Theme struct {
+	FontFamily string
+	FontSize   int
+	TextColor  string   // text color, SVG default black if not set
+	AxisColor  string   // color of axis lines and markers
+	GridColor  string   // color of grid lines
+	Background string   // chart background, transparent if not set
+	Stroke     string   // outline color of bars and slices
+	Palette    []string // series colors, cycled when there are more series than colors
+}
+
+// built-in themes
+var (
+	LightTheme = Theme{
+		FontFamily: "Calibri",
+		FontSize:   14,
+		AxisColor:  "lightgray",
+		GridColor:  "#eeeeee",
+		Stroke:     "gray",
+		Palette:    append([]string(nil), palette.Tableau10...),
+	}
+	// ColorblindTheme is light theme with colors that stay distinct for colorblind readers.
+	ColorblindTheme = Theme{
+		FontFamily: "Calibri",
+		FontSize:   14,
+		AxisColor:  "lightgray",
+		GridColor:  "#eeeeee",
+		Stroke:     "gray",
+		Palette:    append([]string(nil), palette.OkabeIto...),
+	}
+	DarkTheme = Theme{
+		FontFamily: "Calibri",
+		FontSize:   14,
+		TextColor:  "#dddddd",
+		AxisColor:  "#777777",
+		GridColor:  "#444444",
+		Background: "#222222",
+		Stroke:     "#222222",
+		Palette: []string{"#4e9be6", "#3cc8b4", "#f5a623", "#7ed321", "#f25f5c",
+			"#b8b8b8", "#f8e71c", "#bd10e0"},
+	}
+	// PrintTheme is grayscale theme for black and white printing.
+	PrintTheme = Theme{
+		FontFamily: "Calibri",
+		FontSize:   14,
+		TextColor:  "black",
+		AxisColor:  "black",
+		GridColor:  "#cccccc",
+		Background: "white",
+		Stroke:     "black",
+		Palette:    []string{"#222222", "#777777", "#bbbbbb", "white", "#444444", "#999999", "#dddddd"},
+	}
+)
+
+// DefaultTheme is used by all charts that do not have Theme set, change it to
+// restyle all charts at once.
+var DefaultTheme = LightTheme.clone()
+
+// clone returns copy of theme that does not share palette with t.
+func (t Theme) clone() Theme {
+	t.Palette = append([]string(nil), t.Palette...)
+	return t
+}
+
+// themeOf returns theme or DefaultTheme when theme is not set.
+func themeOf(theme *Theme) *Theme {
+	if theme == nil {
+		return &DefaultTheme
+	}
+	return theme
+}
+
+// fontFamily returns font family of theme, of DefaultTheme when it is not set.
+func (t *Theme) fontFamily() string {
+	if t.FontFamily != "" {
+		return t.FontFamily
+	}
+	if DefaultTheme.FontFamily != "" {
+		return DefaultTheme.FontFamily
+	}
+	return "Calibri"
+}
+
+// fontSize returns font size of theme, of DefaultTheme when it is not set.
+func (t *Theme) fontSize() int {
+	if t.FontSize > 0 {
+		return t.FontSize
+	}
+	if DefaultTheme.FontSize > 0 {
+		return DefaultTheme.FontSize
+	}
+	return 14
+}
+
+// colors returns palette of theme, of DefaultTheme when it is empty.
+func (t *Theme) colors() []string {
+	if len(t.Palette) > 0 {
+		return t.Palette
+	}
+	if len(DefaultTheme.Palette) > 0 {
+		return DefaultTheme.Palette
+	}
+	return palette.Tableau10
+}
+
+// Gstyle returns style of chart group: font and text color.
+func (t *Theme) Gstyle() string {
+	style := fmt.Sprintf("font-family:%s; font-size:%d", t.fontFamily(), t.fontSize())
+	if t.TextColor != "" {
+		style += "; fill:" + t.TextColor
+	}
+	return style
+}
+
+// BackgroundStyle returns style of chart background, empty for transparent background.
+func (t *Theme) BackgroundStyle() string {
+	if t.Background == "" {
+		return ""
+	}
+	return "fill:" + t.Background + ";"
+}
+
+// AxisStyle returns style of axis lines and markers.
+func (t *Theme) AxisStyle() string {
+	return fmt.Sprintf("stroke:%s;stroke-width:2px;", t.AxisColor)
+}
+
+// GridStyle returns style of grid lines.
+func (t *Theme) GridStyle() string {
+	return fmt.Sprintf("stroke:%s;stroke-width:1px;", t.GridColor)
+}
+
+// MinorGridStyle returns style of minor grid lines, they are dashed grid lines.
+func (t *Theme) MinorGridStyle() string {
+	return fmt.Sprintf("stroke:%s;stroke-width:1px;stroke-dasharray:2,3;", t.GridColor)
+}
+
+// Color returns palette color of series i.
+func (t *Theme) Color(i int) string {
+	colors := t.colors()
+	return colors[i%len(colors)]
+}
+
+// FillStyle returns style of bars and slices of series i.
+func (t *Theme) FillStyle(i int) string {
+	return fmt.Sprintf("fill:%s;stroke:%s;", t.Color(i), t.Stroke)
+}
+
+// BarCapStyle returns style of translucent circle that marks end of bar.
+func (t *Theme) BarCapStyle() string {
+	return fmt.Sprintf("fill:%s;fill-opacity:0.3;", t.Stroke)
+}
+
+// LineStyle returns style of line of series i.
+func (t *Theme) LineStyle(i int) string {
+	return fmt.Sprintf("fill:none;stroke:%s;stroke-width:2px;", t.Color(i))
+}
+
+// MarkerStyle returns style added to line style of series i for its markers,
+// markers are filled with background.
+func (t *Theme) MarkerStyle(i int) string {
+	if t.Background == "" {
+		return "fill:white;"
+	}
+	return "fill:" + t.Background + ";"
+}
@@ -0,0 +1,222 @@
+// ViChart library for Go
+// Author: Tad Vizbaras 
+// License: http://github.com/tadvi/vichart/blob/master/LICENSE 
+//
+package vichart
+
+import (
+	"time"
+)
+
+// time units used by tick intervals
+const (
+	unitSecond = iota
+	unitMinute
+	unitHour
+	unitDay
+	unitMonth
+	unitYear
+)
+
+// timeInterval is tick step together with label layout used for it.
+type timeInterval struct {
+	unit   int
+	step   int
+	layout string
+}
+
+// duration returns approximate length of the interval, used to pick interval for time span.
+func (ti timeInterval) duration() time.Duration {
+	switch ti.unit {
+	case unitSecond:
+		return time.Duration(ti.step) * time.Second
+	case unitMinute:
+		return time.Duration(ti.step) * time.Minute
+	case unitHour:
+		return time.Duration(ti.step) * time.Hour
+	case unitDay:
+		return time.Duration(ti.step) * 24 * time.Hour
+	case unitMonth:
+		return time.Duration(ti.step) * 30 * 24 * time.Hour
+	}
+	return time.Duration(ti.step) * 365 * 24 * time.Hour
+}
+
+// timeIntervals are candidate tick intervals from finest to coarsest.
+var timeIntervals = []timeInterval{
+	{unitSecond, 1, "15:04:05"},
+	{unitSecond, 2, "15:04:05"},
+	{unitSecond, 5, "15:04:05"},
+	{unitSecond, 10, "15:04:05"},
+	{unitSecond, 15, "15:04:05"},
+	{unitSecond, 30, "15:04:05"},
+	{unitMinute, 1, "15:04"},
+	{unitMinute, 2, "15:04"},
+	{unitMinute, 5, "15:04"},
+	{unitMinute, 10, "15:04"},
+	{unitMinute, 15, "15:04"},
+	{unitMinute, 30, "15:04"},
+	{unitHour, 1, "15:04"},
+	{unitHour, 2, "15:04"},
+	{unitHour, 3, "15:04"},
+	{unitHour, 6, "Jan 2 15:04"},
+	{unitHour, 12, "Jan 2 15:04"},
+	{unitDay, 1, "Jan 2"},
+	{unitDay, 2, "Jan 2"},
+	{unitDay, 7, "Jan 2"},
+	{unitDay, 14, "Jan 2"},
+	{unitMonth, 1, "Jan 2006"},
+	{unitMonth, 3, "Jan 2006"},
+	{unitMonth, 6, "Jan 2006"},
+	{unitYear, 1, "2006"},
+	{unitYear, 2, "2006"},
+	{unitYear, 5, "2006"},
+	{unitYear, 10, "2006"},
+	{unitYear, 25, "2006"},
+	{unitYear, 50, "2006"},
+	{unitYear, 100, "2006"},
+}
+
+// TimeScale maps time values from domain [Min, Max] into pixel range [From, To].
+type TimeScale struct {
+	Min, Max time.Time // domain
+	From, To float64   // pixel range
+}
+
+// NewTimeScale creates scale for times between min and max.
+func NewTimeScale(min, max time.Time, from, to float64) TimeScale {
+	if !max.After(min) {
+		max = min.Add(time.Second)
+	}
+	return TimeScale{Min: min, Max: max, From: from, To: to}
+}
+
+// Map converts time into pixel position.
+func (s TimeScale) Map(t time.Time) float64 {
+	span := s.Max.Sub(s.Min)
+	if span == 0 {
+		return s.From
+	}
+	return s.From + float64(t.Sub(s.Min))/float64(span)*(s.To-s.From)
+}
+
+// Ticks returns tick times aligned to calendar boundaries (whole minutes,
+// hours, days, months) within domain, approximately count of them.
+func (s TimeScale) Ticks(count int) []time.Time {
+	interval := s.interval(count)
+	var ticks []time.Time
+	for t := interval.floor(s.Min); !t.After(s.Max); t = interval.next(t) {
+		if !t.Before(s.Min) {
+			ticks = append(ticks, t)
+		}
+	}
+	return ticks
+}
+
+// Layout returns time layout suitable for ticks generated by Ticks(count),
+// e.g. "15:04" for hourly ticks and "Jan 2" for daily ones.
+func (s TimeScale) Layout(count int) string {
+	return s.interval(count).layout
+}
+
+// TickLabels formats ticks with time layout.
+func (s TimeScale) TickLabels(ticks []time.Time, layout string) []string {
+	labels := make([]string, len(ticks))
+	for i, t := range ticks {
+		labels[i] = t.Format(layout)
+	}
+	return labels
+}
+
+// interval picks finest interval that gives no more than count ticks.
+func (s TimeScale) interval(count int) timeInterval {
+	if count < 2 {
+		count = 2
+	}
+	span := float64(s.Max.Sub(s.Min))
+	for _, ti := range timeIntervals {
+		if span/float64(ti.duration()) < float64(count) {
+			return ti
+		}
+	}
+	return timeIntervals[len(timeIntervals)-1]
+}
+
+// floor rounds time down to interval boundary in time location.
+func (ti timeInterval) floor(t time.Time) time.Time {
+	year, month, day := t.Date()
+	hour, min, sec := t.Clock()
+	loc := t.Location()
+	switch ti.unit {
+	case unitSecond:
+		return time.Date(year, month, day, hour, min, sec-sec%ti.step, 0, loc)
+	case unitMinute:
+		return time.Date(year, month, day, hour, min-min%ti.step, 0, 0, loc)
+	case unitHour:
+		return time.Date(year, month, day, hour-hour%ti.step, 0, 0, 0, loc)
+	case unitDay:
+		return time.Date(year, month, day-(day-1)%ti.step, 0, 0, 0, 0, loc)
+	case unitMonth:
+		m := int(month) - 1
+		return time.Date(year, time.Month(m-m%ti.step+1), 1, 0, 0, 0, 0, loc)
+	}
+	return time.Date(year-year%ti.step, 1, 1, 0, 0, 0, 0, loc)
+}
+
+// next returns next tick after t, day based ticks restart at first day of month
+// so they stay on the same days every month.
+func (ti timeInterval) next(t time.Time) time.Time {
+	switch ti.unit {
+	case unitSecond:
+		return t.Add(time.Duration(ti.step) * time.Second)
+	case unitMinute:
+		return t.Add(time.Duration(ti.step) * time.Minute)
+	case unitHour:
+		// floor keeps ticks on the same hours across daylight saving changes,
+		// hour skipped by the change is moved back by an hour and is not used
+		n := ti.floor(t.Add(time.Duration(ti.step) * time.Hour))
+		if !n.After(t) || n.Hour()%ti.step != 0 {
+			n = ti.floor(t.Add(time.Duration(2*ti.step) * time.Hour))
+		}
+		return n
+	case unitDay:
+		// skip short last step of the month, e.g. Mar 29 followed by Apr 1
+		year, month, _ := t.Date()
+		days := time.Date(year, month+1, 0, 0, 0, 0, 0, t.Location()).Day()
+		n := t.AddDate(0, 0, ti.step)
+		if n.Month() != month || n.Day() > days-ti.step/2 {
+			return time.Date(year, month+1, 1, 0, 0, 0, 0, t.Location())
+		}
+		return n
+	case unitMonth:
+		return t.AddDate(0, ti.step, 0)
+	}
+	return t.AddDate(ti.step, 0, 0)
+}
+
+// timeRange returns earliest and latest time.
+func timeRange(times []time.Time) (min, max time.Time) {
+	for i, t := range times {
+		if i == 0 || t.Before(min) {
+			min = t
+		}
+		if i == 0 || t.After(max) {
+			max = t
+		}
+	}
+	return min, max
+}
+
+// timeAxisTicks returns pixel positions and labels for time axis markers, layout
+// is picked by tick interval when not set.
+func timeAxisTicks(scale TimeScale, layout string) ([]float64, []string) {
+	if layout == "" {
+		layout = scale.Layout(ScaleTicks)
+	}
+	ticks := scale.Ticks(ScaleTicks)
+	pos := make([]float64, len(ticks))
+	for i, t := range ticks {
+		pos[i] = scale.Map(t)
+	}
+	return pos, scale.TickLabels(ticks, layout)
+}
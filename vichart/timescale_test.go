@@ -0,0 +1,53 @@
+// ViChart library for Go
+// Author: Tad Vizbaras 
+// License: http://github.com/tadvi/vichart/blob/master/LICENSE 
+//
+package vichart
+
+import (
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestTimeScaleTicks(t *testing.T) {
+	newYork, err := time.LoadLocation("America/New_York")
+	if err != nil {
+		t.Skip("time zone database is not available:", err)
+	}
+	date := func(year int, month time.Month, day, hour int, loc *time.Location) time.Time {
+		return time.Date(year, month, day, hour, 0, 0, 0, loc)
+	}
+	tests := []struct {
+		name     string
+		min, max time.Time
+		count    int
+		want     []string // ticks in "2006-01-02 15:04 MST" layout
+	}{
+		{"months from month end", date(2024, 1, 31, 0, time.UTC), date(2024, 6, 30, 0, time.UTC), 6,
+			[]string{"2024-02-01 00:00 UTC", "2024-03-01 00:00 UTC", "2024-04-01 00:00 UTC",
+				"2024-05-01 00:00 UTC", "2024-06-01 00:00 UTC"}},
+		{"weeks across month ends", date(2024, 1, 20, 0, time.UTC), date(2024, 3, 5, 0, time.UTC), 8,
+			[]string{"2024-01-22 00:00 UTC", "2024-02-01 00:00 UTC", "2024-02-08 00:00 UTC",
+				"2024-02-15 00:00 UTC", "2024-02-22 00:00 UTC", "2024-03-01 00:00 UTC"}},
+		{"days across month end", date(2024, 4, 28, 0, time.UTC), date(2024, 5, 3, 0, time.UTC), 8,
+			[]string{"2024-04-28 00:00 UTC", "2024-04-29 00:00 UTC", "2024-04-30 00:00 UTC",
+				"2024-05-01 00:00 UTC", "2024-05-02 00:00 UTC", "2024-05-03 00:00 UTC"}},
+		{"daylight saving start", date(2024, 3, 10, 0, newYork), date(2024, 3, 10, 12, newYork), 6,
+			[]string{"2024-03-10 00:00 EST", "2024-03-10 04:00 EDT", "2024-03-10 06:00 EDT",
+				"2024-03-10 08:00 EDT", "2024-03-10 10:00 EDT", "2024-03-10 12:00 EDT"}},
+		{"daylight saving end", date(2024, 11, 3, 0, newYork), date(2024, 11, 3, 12, newYork), 6,
+			[]string{"2024-11-03 00:00 EDT", "2024-11-03 03:00 EST", "2024-11-03 06:00 EST",
+				"2024-11-03 09:00 EST", "2024-11-03 12:00 EST"}},
+		{"days across daylight saving", date(2024, 3, 8, 0, newYork), date(2024, 3, 12, 0, newYork), 6,
+			[]string{"2024-03-08 00:00 EST", "2024-03-09 00:00 EST", "2024-03-10 00:00 EST",
+				"2024-03-11 00:00 EDT", "2024-03-12 00:00 EDT"}},
+	}
+	for _, tt := range tests {
+		s := NewTimeScale(tt.min, tt.max, 0, 100)
+		got := s.TickLabels(s.Ticks(tt.count), "2006-01-02 15:04 MST")
+		if !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("%s: Ticks(%d) = %q, want %q", tt.name, tt.count, got, tt.want)
+		}
+	}
+}
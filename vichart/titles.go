@@ -0,0 +1,97 @@
+// ViChart library for Go
+// Author: Tad Vizbaras 
+// License: http://github.com/tadvi/vichart/blob/master/LICENSE 
+//
+package vichart
+
+import (
+	"fmt"
+	"github.com/ajstarks/svgo"
+)
+
+const (
+	TitleStyle     = "font-size:125%;font-weight:bold;text-anchor:middle;"
+	SubtitleStyle  = "font-size:90%;text-anchor:middle;"
+	AxisTitleStyle = "font-size:85%;text-anchor:middle;"
+	CaptionStyle   = "font-size:70%;text-anchor:start;"
+	SourceStyle    = "font-size:70%;text-anchor:end;"
+
+	TitleHeight     = 24
+	SubtitleHeight  = 18
+	AxisTitleHeight = 18
+	CaptionHeight   = 16
+)
+
+// plotArea is part of chart left for plot after titles are placed, edges are
+// in pixels from top left corner of the chart.
+type plotArea struct {
+	left, top, right, bottom int
+}
+
+// chartTitles are texts placed around plot area.
+type chartTitles struct {
+	title, subtitle      string
+	xAxis, yAxis, y2Axis string
+	caption, source      string
+}
+
+// area returns plot area of chart width x height that is left after room is
+// made for titles.
+func (t chartTitles) area(width, height int) plotArea {
+	area := plotArea{0, 0, width, height}
+	if t.title != "" {
+		area.top += TitleHeight
+	}
+	if t.subtitle != "" {
+		area.top += SubtitleHeight
+	}
+	if t.caption != "" || t.source != "" {
+		area.bottom -= CaptionHeight
+	}
+	if t.xAxis != "" {
+		area.bottom -= AxisTitleHeight
+	}
+	if t.yAxis != "" {
+		area.left += AxisTitleHeight
+	}
+	if t.y2Axis != "" {
+		area.right -= AxisTitleHeight
+	}
+	return area
+}
+
+// draw draws titles around plot area of chart width x height. Y axis titles
+// are rotated and centered along plot height.
+func (t chartTitles) draw(canvas *svg.SVG, width, height int) {
+	area := t.area(width, height)
+	y := 0
+	if t.title != "" {
+		y += TitleHeight
+		canvas.Text(width/2, y-6, t.title, joinStyles(class("vichart-title"), TitleStyle))
+	}
+	if t.subtitle != "" {
+		y += SubtitleHeight
+		canvas.Text(width/2, y-5, t.subtitle, joinStyles(class("vichart-subtitle"), SubtitleStyle))
+	}
+	if t.xAxis != "" {
+		canvas.Text((area.left+area.right)/2, area.bottom+AxisTitleHeight-4, t.xAxis,
+			joinStyles(class("vichart-axis-title"), AxisTitleStyle))
+	}
+	middle := (area.top + area.bottom) / 2
+	if t.yAxis != "" {
+		x := area.left - 5
+		canvas.Text(x, middle, t.yAxis, joinStyles(class("vichart-axis-title"), AxisTitleStyle,
+			fmt.Sprintf(`transform="rotate(-90,%d,%d)"`, x, middle)))
+	}
+	if t.y2Axis != "" {
+		x := area.right + 5
+		canvas.Text(x, middle, t.y2Axis, joinStyles(class("vichart-axis-title"), AxisTitleStyle,
+			fmt.Sprintf(`transform="rotate(90,%d,%d)"`, x, middle)))
+	}
+	if t.caption != "" {
+		canvas.Text(5, height-4, t.caption, joinStyles(class("vichart-caption"), CaptionStyle))
+	}
+	if t.source != "" {
+		canvas.Text(width-5, height-4, t.source, joinStyles(class("vichart-source"), SourceStyle))
+	}
+}
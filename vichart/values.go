@@ -0,0 +1,94 @@
+// ViChart library for Go
+// Author: Tad Vizbaras 
+// License: http://github.com/tadvi/vichart/blob/master/LICENSE 
+//
+package vichart
+
+import (
+	"github.com/ajstarks/svgo"
+	"math"
+)
+
+const (
+	ValueStyle = "font-size:75%;"
+)
+
+// ValueLabel selects where bar values are written.
+type ValueLabel int
+
+const (
+	ValueLabelDefault ValueLabel = iota // chart default, values are written only by HBarChart, outside of bars
+	ValueLabelNone
+	ValueLabelOutside // past the end of bar, on top of the whole stack for stacked bars
+	ValueLabelInside  // inside the bar at its end
+	ValueLabelCenter  // in the middle of the bar
+)
+
+// valueHeight returns space needed above vertical bars for value labels.
+func valueHeight(place ValueLabel, size float64) int {
+	if place == ValueLabelOutside {
+		return int(size*labelScale) + 3
+	}
+	return 0
+}
+
+// valueDepth returns space needed below vertical bars for value labels of
+// negative bars, bars go below zero when scale min is negative.
+func valueDepth(place ValueLabel, size float64, min float64) int {
+	if min < 0 {
+		return valueHeight(place, size)
+	}
+	return 0
+}
+
+// drawVBarValue writes text of vertical bar at x, y of width w that grows up
+// by h pixels, negative h grows down. Text inside of bar is not written when
+// the bar is too small for it.
+func drawVBarValue(canvas *svg.SVG, x, y, w, h int, text string, place ValueLabel, size float64, style string) {
+	labelSize := size * labelScale
+	cx, end := x+w/2, y-h
+	if place != ValueLabelOutside &&
+		(textWidth(text, labelSize)+2 > float64(w) || labelSize+2 > math.Abs(float64(h))) {
+		return
+	}
+	// text baseline is moved down by third of label size to center text at point
+	shift := int(labelSize / 3)
+	var ty int
+	switch {
+	case place == ValueLabelCenter:
+		ty = y - h/2 + shift
+	case place == ValueLabelOutside && h >= 0, place == ValueLabelInside && h < 0:
+		// above bar end
+		ty = end - 3
+	default:
+		// below bar end
+		ty = end + int(labelSize)
+	}
+	canvas.Text(cx, ty, text, joinStyles(class("vichart-value"), style, "text-anchor:middle;"))
+}
+
+// drawHBarValue writes text of horizontal bar of thickness h at y that grows
+// right from x by w pixels, negative w grows left. Inset is space between bar
+// end and text. Text inside of bar is not written when the bar is too short
+// for it.
+func drawHBarValue(canvas *svg.SVG, x, y, h, w, inset int, text string, place ValueLabel, size float64, style string) {
+	labelSize := size * labelScale
+	if place != ValueLabelOutside && textWidth(text, labelSize)+float64(2*inset) > math.Abs(float64(w)) {
+		return
+	}
+	ty, end := y+h/2, x+w
+	style = joinStyles(class("vichart-value"), style)
+	switch {
+	case place == ValueLabelCenter:
+		canvas.Text(x+w/2, ty, text, joinStyles(style, "text-anchor:middle;baseline-shift:-33%"))
+	case place == ValueLabelOutside && w < 0:
+		// value text goes right of zero so it does not run into labels
+		canvas.Text(x+inset, ty, text, joinStyles(style, "text-anchor:start;baseline-shift:-33%"))
+	case place == ValueLabelOutside:
+		canvas.Text(end+inset, ty, text, joinStyles(style, "text-anchor:start;baseline-shift:-33%"))
+	case w < 0:
+		canvas.Text(end+inset, ty, text, joinStyles(style, "text-anchor:start;baseline-shift:-33%"))
+	default:
+		canvas.Text(end-inset, ty, text, joinStyles(style, "text-anchor:end;baseline-shift:-33%"))
+	}
+}
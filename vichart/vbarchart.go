@@ -7,46 +7,90 @@ package vichart
 import (
 	"fmt"
 	"github.com/ajstarks/svgo"
+	"time"
 )
 
+const (
+	VBarPadding = 0.2
+	NoPadding   = -1 // bar padding that leaves no space between bars, zero padding is replaced by default
+)
+
+// Deprecated: VBarChart takes its default styles from Theme.
 const (
 	VBarGstyle      = "font-family:Calibri; font-size:14"
 	VBarLineXYStyle = "stroke:lightgray;stroke-width:2px;"
 	VBarLineStyle   = "fill:navy;stroke:navy;stroke-width:2px;"
 	VBarBarStyle    = "fill:teal;stroke:gray;"
+)
 
-	VBarGutterLeft  = 40
-	VBarGutterRight = 40
-	VBarGutterTop   = 40
-
-	VBarSpacing       = 16
-	VBarBarWidth      = 15
+// Deprecated: gutters and legend offset that are not set are sized from
+// labels, legend and chart size.
+const (
+	VBarGutterLeft    = 40
+	VBarGutterRight   = 40
+	VBarGutterTop     = 40
 	VBarLegendXOffset = 40
 )
 
+// Deprecated: bars fill plot width when BarSpacing and BarWidth are not set.
+const (
+	VBarSpacing  = 16
+	VBarBarWidth = 15
+)
+
 type VBarChart struct {
 	Svg           *svg.SVG
 	Width, Height int
-	BarValues     []int // chart bar values
-	LineValues    []int // chart line values
-	MaxBarValue   int   // chart max value, used for scaling all the bar values
-	MaxLineValue  int   // chart max value, used for scaling all the line values
+	BarValues     []float64 // chart bar values, negative values grow down from zero
+	LineValues    []float64 // chart line values
+	MaxBarValue   float64   // chart max value, used for scaling all the bar values, computed from BarValues if not set
+	MaxLineValue  float64   // chart max value, used for scaling all the line values, computed from LineValues if not set
+	MinBarValue   float64   // chart min value for bars, computed from BarValues if not set
+	MinLineValue  float64   // chart min value for line, computed from LineValues if not set
 
 	// optional fields below
-	BarSpacing  int
-	BarWidth    int
-	LabelsX     []string
+	BarSpacing  int         // distance between bar starts, bars fill plot width if not set
+	BarWidth    int         // bar width, computed from bar slot and BarPadding if not set
+	BarPadding  float64     // part of bar slot left empty between bars, VBarPadding if not set, NoPadding for bars that touch
+	LabelsX     []string    // labels under bar centers, spread over bars if there are fewer labels than bars
+	LabelFit    LabelFit    // how LabelsX that do not fit next to each other are drawn
+	TimesX      []time.Time // optional time of every bar, bars are placed on time X line when set
+	TimeFormat  string      // time layout for X labels with TimesX, picked by tick interval if not set
 	LabelsY1    []string
 	LabelsY2    []string
-	GutterLeft  int // left gutter for the chart, used to fit left labels
-	GutterRight int // right gutter for the chart, used to fit last bottom label
-	GutterTop   int // top gutter for the chart, used top label
+	FormatY1    Formatter // formats generated left Y line labels, with precision of tick step if not set
+	FormatY2    Formatter // formats generated right Y line labels
+	ScaleY1     ScaleType // scale of bars and left Y line, linear if not set
+	ScaleY2     ScaleType // scale of line and right Y line, linear if not set
+	Grid        Grid      // gridlines drawn behind bars, none if not set
+	GutterLeft  int       // left gutter for the chart, used to fit left labels
+	GutterRight int       // right gutter for the chart, used to fit last bottom label
+	GutterTop   int       // top gutter for the chart, used top label
+
+	// titles, plot area shrinks to make room for them
+	Title       string
+	Subtitle    string
+	XAxisTitle  string
+	YAxisTitle  string // title of left Y line
+	Y2AxisTitle string // title of right Y line
+	Caption     string // footnote at bottom left
+	Source      string // data source at bottom right
 
-	// styles
-	Gstyle      string
-	LineXYStyle string
-	LineStyle   string
-	BarStyle    string
+	// styles, taken from Theme if not set
+	Classes        bool   // emit class names styled by StyleSheet instead of inline styles, DrawAt expects the document to include it
+	StyleSheetURL  string // with Classes, Draw imports this CSS instead of embedding StyleSheet of Theme
+	Theme          *Theme // DefaultTheme is used if not set
+	Gstyle         string
+	LineXYStyle    string
+	GridStyle      string
+	MinorGridStyle string
+	LineStyle      string
+	BarStyle       string
+	ValueStyle     string
+
+	// value labels, not written if not set
+	ValueLabels ValueLabel
+	ValueFormat Formatter // formats value labels, FormatY1 or shortest decimal form if not set
 
 	// legend related
 	BarLegend  string
@@ -56,161 +100,184 @@ type VBarChart struct {
 	LegendXOffset int
 }
 
-// Draw produces chart on screen, main entry point.
-func (chart *VBarChart) Draw() error {
-	canvas := chart.Svg
-	if chart.Svg == nil {
-		return fmt.Errorf("Missing pointer to svg.SVG in field Svg.")
-	}
-	if chart.Width < 10 || chart.Height < 10 {
-		return fmt.Errorf("Incorrect Width or Height value.")
+// Validate checks that all required chart fields are set.
+func (chart *VBarChart) Validate() error {
+	if err := validateCanvas(chart.Svg, chart.Width, chart.Height); err != nil {
+		return err
 	}
 	if len(chart.BarValues) == 0 {
 		return fmt.Errorf("Missing BarValues for the chart.")
 	}
-	if chart.MaxBarValue == 0 {
-		return fmt.Errorf("Missing chart MaxBarValue.")
+	if len(chart.LineValues) > 0 && len(chart.BarValues) != len(chart.LineValues) {
+		return fmt.Errorf("Number of BarValues does not match number of LineValues.")
 	}
-	if chart.MaxLineValue == 0 && len(chart.LineValues) > 0 {
-		return fmt.Errorf("Missing chart MaxLineValue.")
+	if len(chart.TimesX) > 0 && len(chart.BarValues) != len(chart.TimesX) {
+		return fmt.Errorf("Number of BarValues does not match number of TimesX.")
 	}
-	if len(chart.BarValues) != len(chart.LineValues) {
-		return fmt.Errorf("Number of BarValues does not match number of LineValues.")
+	if err := validateValues("BarValues", chart.BarValues); err != nil {
+		return err
+	}
+	if err := validateValues("LineValues", chart.LineValues); err != nil {
+		return err
+	}
+	if !finite(chart.MinBarValue, chart.MaxBarValue, chart.MinLineValue, chart.MaxLineValue) {
+		return fmt.Errorf("Min and max values must be finite numbers.")
+	}
+	if err := validateScale(chart.ScaleY1, "BarValues", chart.MinBarValue, chart.BarValues); err != nil {
+		return err
+	}
+	if err := validateScale(chart.ScaleY2, "LineValues", chart.MinLineValue, chart.LineValues); err != nil {
+		return err
+	}
+	bar, line := chart.scales(1, 0)
+	if err := validateDomain("BarValues", bar); err != nil {
+		return err
 	}
-	// default to sensible constants if value is not set
+	if len(chart.LineValues) > 0 || len(chart.LabelsY2) > 0 {
+		return validateDomain("LineValues", line)
+	}
+	return nil
+}
+
+// SetDefaults sets sensible constants for optional fields that are not set.
+func (chart *VBarChart) SetDefaults() {
+	theme := stylesOf(chart.Theme, chart.Classes)
 	if chart.LineXYStyle == "" {
-		chart.LineXYStyle = VBarLineXYStyle
+		chart.LineXYStyle = theme.AxisStyle()
+	}
+	if chart.GridStyle == "" {
+		chart.GridStyle = theme.GridStyle()
+	}
+	if chart.MinorGridStyle == "" {
+		chart.MinorGridStyle = theme.MinorGridStyle()
 	}
 	if chart.Gstyle == "" {
-		chart.Gstyle = VBarGstyle
+		chart.Gstyle = theme.Gstyle()
 	}
 	if chart.LineStyle == "" {
-		chart.LineStyle = VBarLineStyle
+		chart.LineStyle = theme.LineStyle(0)
 	}
 	if chart.BarStyle == "" {
-		chart.BarStyle = VBarBarStyle
-	}
-	if chart.GutterRight == 0 {
-		chart.GutterRight = VBarGutterRight
+		chart.BarStyle = theme.FillStyle(1)
 	}
-	if chart.GutterTop == 0 {
-		chart.GutterTop = VBarGutterTop
+	if chart.ValueLabels == ValueLabelDefault {
+		chart.ValueLabels = ValueLabelNone
 	}
-	if chart.BarSpacing == 0 {
-		chart.BarSpacing = VBarSpacing
+	if chart.ValueFormat == nil {
+		chart.ValueFormat = chart.FormatY1
 	}
-	if chart.BarWidth == 0 {
-		chart.BarWidth = VBarBarWidth
+	if chart.ValueFormat == nil {
+		chart.ValueFormat = formatValue
 	}
-	if chart.GutterLeft == 0 {
-		chart.GutterLeft = VBarGutterLeft
+	if chart.ValueStyle == "" {
+		chart.ValueStyle = ValueStyle
 	}
-	if chart.LegendXOffset == 0 {
-		chart.LegendXOffset = VBarLegendXOffset
+	if chart.BarPadding == 0 {
+		chart.BarPadding = VBarPadding
 	}
+}
 
-	// start SVG
-	canvas.Start(chart.Width, chart.Height)
-	canvas.Gstyle(chart.Gstyle)
-	x, y := chart.GutterLeft, chart.Height-42
-	bHeight := float64(y - chart.GutterTop)
-	bWidth := float64(chart.Width - chart.GutterRight - x)
+// scales returns scales of bars and line between base and top.
+func (chart *VBarChart) scales(base, top float64) (bar, line Scale) {
+	bar = axisScale(chart.ScaleY1, chart.MinBarValue, chart.MaxBarValue, chart.BarValues, base, top)
+	line = axisScale(chart.ScaleY2, chart.MinLineValue, chart.MaxLineValue, chart.LineValues, base, top)
+	return bar, line
+}
 
-	xoffset := x
-	for i, _ := range chart.BarValues {
-		// scale value to fit in chart pixels
-		val := float64(chart.BarValues[i])
-		chartVal := int((val / float64(chart.MaxBarValue)) * bHeight)
-		chart.drawMeter(xoffset, y+3, chart.BarWidth, chartVal)
-		// draw line on the chart
-		if i > 0 && len(chart.LineValues) > 0 {
-			valLine1 := float64(chart.LineValues[i-1])
-			chartValLine1 := int((valLine1 / float64(chart.MaxLineValue)) * bHeight)
-			valLine2 := float64(chart.LineValues[i])
-			chartValLine2 := int((valLine2 / float64(chart.MaxLineValue)) * bHeight)
-
-			xpos := xoffset + chart.BarWidth/2
-			canvas.Line(xpos-chart.BarSpacing, y-chartValLine1+3, xpos, y-chartValLine2+3, chart.LineStyle)
-		}
+// Draw produces chart on screen, main entry point.
+func (chart *VBarChart) Draw() error {
+	return drawChart(chart)
+}
 
-		xoffset += chart.BarSpacing
-	}
+// DrawAt draws chart into its own viewport at x, y of existing SVG document
+// without starting or ending it.
+func (chart *VBarChart) DrawAt(x, y int) error {
+	return drawChartAt(chart, x, y, false)
+}
 
-	// bottom line markers and labels
-	canvas.Line(x, y+12, chart.Width-chart.GutterRight, y+12, chart.LineXYStyle)
-	labels := len(chart.LabelsX)
-	// display bottom line labels
-	for i := 0; i < labels; i++ {
-		step := bWidth / float64(labels-1)
-		xoffset := int(float64(i) * step)
-		canvas.Text(x+xoffset, y+30, chart.LabelsX[i], "font-size:75%;text-anchor:middle;")
-		canvas.Line(x+xoffset, y+6, x+xoffset, y+18, chart.LineXYStyle)
+// SetCanvas points chart to canvas and sets chart size.
+func (chart *VBarChart) SetCanvas(canvas *svg.SVG, width, height int) {
+	chart.Svg = canvas
+	chart.Width, chart.Height = width, height
+}
 
-	}
+// frame returns canvas of the chart and styles of its document.
+func (chart *VBarChart) frame() chartFrame {
+	return chartFrame{chart.Svg, chart.Width, chart.Height, chart.Gstyle, chart.Theme, chart.Classes,
+		chart.StyleSheetURL}
+}
 
-	// left vertical Y line
-	chart.drawYLine(x, y+2)
-	chart.drawYLineText(x-16, y, chart.LabelsY1, true)
-	// right vertical Y line
-	chart.drawYLine(chart.Width-chart.GutterRight+12, y+2)
-	chart.drawYLineText(chart.Width-chart.GutterRight+12, y, chart.LabelsY2, false)
+// seriesCount returns number of series styled by style sheet, line is
+// series 0 and bars are series 1.
+func (chart *VBarChart) seriesCount() int {
+	return 2
+}
 
-	chart.drawLegend(x)
+// copy returns copy of chart for single draw, classes turns on class names.
+func (chart *VBarChart) copy(classes bool) drawer {
+	c := *chart
+	c.Classes = c.Classes || classes
+	return &c
+}
 
-	canvas.Gend()
-	canvas.End()
+// draw renders chart body.
+func (chart *VBarChart) draw() error {
+	p := chart.plot()
+	p.start()
+	for i, val := range chart.BarValues {
+		// scale value to fit in chart pixels
+		chartVal := int(p.barScale.Base() - p.barScale.barEnd(val))
+		chart.drawMeter(p.offsets[i], p.zero, p.barWidth, chartVal)
+		if chart.ValueLabels != ValueLabelNone {
+			drawVBarValue(chart.Svg, p.offsets[i], p.zero, p.barWidth, chartVal, chart.ValueFormat(val),
+				chart.ValueLabels, fontSize(chart.Theme), chart.ValueStyle)
+		}
+	}
+	p.finish()
 	return nil
 }
 
-// drawLegend draws chart legend.
-func (chart *VBarChart) drawLegend(x int) {
-	canvas := chart.Svg
-	canvas.Rect(x+chart.LegendXOffset, 10, 40, 10, chart.BarStyle)
-	canvas.Text(x+chart.LegendXOffset+50, 20, chart.BarLegend, "font-size:75%;")
-
-	xpos := x + chart.LegendXOffset + 150
-	canvas.Line(xpos, 15, xpos+40, 15, chart.LineStyle)
-	canvas.Text(x+chart.LegendXOffset+200, 20, chart.LineLegend, "font-size:75%;")
+// plot returns plot of bars, axes and line of the chart.
+func (chart *VBarChart) plot() *vbarPlot {
+	return &vbarPlot{
+		canvas: chart.Svg, width: chart.Width, height: chart.Height, theme: chart.Theme, titles: chart.titles(),
+		layout: chartLayout{gutterLeft: chart.GutterLeft, gutterRight: chart.GutterRight, gutterTop: chart.GutterTop,
+			legendX: chart.LegendXOffset},
+		count: len(chart.BarValues), barSpacing: chart.BarSpacing, barWidth: chart.BarWidth, padding: chart.BarPadding,
+		labelsX: chart.LabelsX, labelFit: chart.LabelFit, timesX: chart.TimesX, timeFormat: chart.TimeFormat,
+		labelsY1: chart.LabelsY1, labelsY2: chart.LabelsY2, formatY1: chart.FormatY1, formatY2: chart.FormatY2,
+		grid: chart.Grid, valueLabels: chart.ValueLabels, lineValues: chart.LineValues,
+		legend: chart.legendItems(), scales: chart.scales,
+		lineXYStyle: chart.LineXYStyle, gridStyle: chart.GridStyle, minorGridStyle: chart.MinorGridStyle,
+		lineStyle: chart.LineStyle,
+	}
 }
 
-// drawYLineText produces text for Y line.
-func (chart *VBarChart) drawYLineText(x, h int, labels []string, left bool) {
-	canvas := chart.Svg
-
-	style := "font-size:75%;text-anchor:start;baseline-shift:-75%"
-	if left {
-		style = "font-size:75%;text-anchor:end;baseline-shift:-75%"
-	}
-	labelsCount := len(labels)
-	for i := 0; i < labelsCount; i++ {
-		step := float64(h-chart.GutterTop) / float64(labelsCount-1)
-		yoffset := int(float64(i) * step)
-		canvas.Text(x, yoffset+chart.GutterTop, labels[labelsCount-i-1], style)
-	}
+// titles returns chart titles.
+func (chart *VBarChart) titles() chartTitles {
+	return chartTitles{chart.Title, chart.Subtitle, chart.XAxisTitle, chart.YAxisTitle,
+		chart.Y2AxisTitle, chart.Caption, chart.Source}
 }
 
-// drawYLine draws Y line on screen.
-func (chart *VBarChart) drawYLine(x, y int) {
-	canvas := chart.Svg
-	canvas.Line(x-8, chart.GutterTop, x-8, y, chart.LineXYStyle)
-
-	height := float64(y - chart.GutterTop)
-	step := height / 10
-	pos := 0
-	for i := 0.0; i <= height; i += step {
-		marker := int(height-i) + 1 + chart.GutterTop
-		if pos == 0 || pos == 5 || pos == 10 {
-			canvas.Line(x-2, marker, x-14, marker, chart.LineXYStyle)
-		} else {
-			canvas.Line(x-5, marker, x-11, marker, chart.LineXYStyle)
-		}
-		pos += 1
+// legendItems returns legend entries for bars and line that have legend set.
+func (chart *VBarChart) legendItems() []legendItem {
+	var items []legendItem
+	if chart.BarLegend != "" {
+		items = append(items, legendItem{label: chart.BarLegend, style: chart.BarStyle})
+	}
+	if chart.LineLegend != "" {
+		items = append(items, legendItem{label: chart.LineLegend, style: chart.LineStyle, line: true})
 	}
+	return items
 }
 
-// drawMeter draws bar on screen.
+// drawMeter draws bar on screen, negative values grow down from y.
 func (chart *VBarChart) drawMeter(x, y, w, value int) {
 	canvas := chart.Svg
 	corner := w
+	if value < 0 {
+		canvas.Roundrect(x, y, corner, -value, 0, 0, chart.BarStyle)
+		return
+	}
 	canvas.Roundrect(x, y-value, corner, value, 0, 0, chart.BarStyle)
 }
@@ -0,0 +1,124 @@
+// ViChart library for Go
+// Author: Tad Vizbaras 
+// License: http://github.com/tadvi/vichart/blob/master/LICENSE 
+//
+package vichart
+
+import (
+	"bytes"
+	"strconv"
+	"testing"
+
+	"github.com/ajstarks/svgo"
+)
+
+// attr returns integer attribute of element.
+func attr(t *testing.T, e element, name string) int {
+	t.Helper()
+	val, err := strconv.Atoi(e.attrs[name])
+	if err != nil {
+		t.Fatalf("%s %s: %v", e.name, name, err)
+	}
+	return val
+}
+
+// hlines returns Y positions of horizontal lines longer than length.
+func hlines(t *testing.T, doc []element, length int) []int {
+	t.Helper()
+	var ys []int
+	for _, e := range named(doc, "line") {
+		y := attr(t, e, "y1")
+		if y == attr(t, e, "y2") && attr(t, e, "x2")-attr(t, e, "x1") > length {
+			ys = append(ys, y)
+		}
+	}
+	return ys
+}
+
+func TestVBarNegativeBaseline(t *testing.T) {
+	var buf bytes.Buffer
+	chart := VBarChart{Svg: svg.New(&buf), Width: 400, Height: 300, BarValues: []float64{3, -2},
+		LabelsX: []string{"a", "b"}}
+	doc := render(t, &chart, &buf)
+	bars := named(doc, "rect")
+	if len(bars) != 2 {
+		t.Fatalf("draws %d bars, want 2", len(bars))
+	}
+	lines := hlines(t, doc, 100) // zero baseline and X line
+	if len(lines) != 2 {
+		t.Fatalf("draws horizontal lines at %v, want zero baseline and X line", lines)
+	}
+	zero, xLine := lines[0], lines[1]
+	if up := bars[0]; attr(t, up, "y")+attr(t, up, "height") != zero {
+		t.Errorf("positive bar ends at %d, want zero baseline %d", attr(t, up, "y")+attr(t, up, "height"), zero)
+	}
+	down := bars[1]
+	if attr(t, down, "y") != zero || attr(t, down, "height") <= 0 {
+		t.Errorf("negative bar starts at %d with height %d, want down from zero baseline %d",
+			attr(t, down, "y"), attr(t, down, "height"), zero)
+	}
+	if bottom := attr(t, down, "y") + attr(t, down, "height"); bottom >= xLine {
+		t.Errorf("negative bar ends at %d, want above X line %d", bottom, xLine)
+	}
+}
+
+func TestVBarPadding(t *testing.T) {
+	var buf bytes.Buffer
+	tests := []struct {
+		padding float64
+		touch   bool
+	}{
+		{0, false}, // VBarPadding
+		{0.5, false},
+		{NoPadding, true},
+	}
+	for _, tt := range tests {
+		chart := VBarChart{Svg: svg.New(&buf), Width: 400, Height: 300, BarValues: []float64{1, 2, 3},
+			BarPadding: tt.padding}
+		bars := named(render(t, &chart, &buf), "rect")
+		if len(bars) != 3 {
+			t.Fatalf("padding %v: draws %d bars, want 3", tt.padding, len(bars))
+		}
+		gap := attr(t, bars[1], "x") - attr(t, bars[0], "x") - attr(t, bars[0], "width")
+		if touch := gap <= 1; touch != tt.touch {
+			t.Errorf("padding %v: gap between bars is %d, want bars touching %v", tt.padding, gap, tt.touch)
+		}
+	}
+}
+
+func TestVBarValueLabels(t *testing.T) {
+	var buf bytes.Buffer
+	chart := VBarChart{Svg: svg.New(&buf), Width: 400, Height: 300, BarValues: []float64{3, -2},
+		LabelsX: []string{"a", "b"}, ValueLabels: ValueLabelOutside}
+	doc := render(t, &chart, &buf)
+	bars := named(doc, "rect")
+	lines := hlines(t, doc, 100)
+	if len(bars) != 2 || len(lines) != 2 {
+		t.Fatalf("draws %d bars and horizontal lines at %v, want 2 bars, zero baseline and X line", len(bars), lines)
+	}
+	xLine := lines[1]
+	// value labels are centered over bars, Y line labels share their text
+	labels := map[string]int{}
+	for _, e := range named(doc, "text") {
+		for _, bar := range bars {
+			if attr(t, e, "x") == attr(t, bar, "x")+attr(t, bar, "width")/2 {
+				labels[e.text] = attr(t, e, "y")
+			}
+		}
+	}
+	up, ok := labels["3"]
+	if !ok {
+		t.Fatalf("value label of positive bar is not drawn")
+	}
+	if top := attr(t, bars[0], "y"); up >= top {
+		t.Errorf("value label of positive bar is at %d, want above bar top %d", up, top)
+	}
+	down, ok := labels["-2"]
+	if !ok {
+		t.Fatalf("value label of negative bar is not drawn")
+	}
+	bottom := attr(t, bars[1], "y") + attr(t, bars[1], "height")
+	if down <= bottom || down >= xLine {
+		t.Errorf("value label of negative bar is at %d, want between bar end %d and X line %d", down, bottom, xLine)
+	}
+}
@@ -0,0 +1,190 @@
+// ViChart library for Go
+// Author: Tad Vizbaras 
+// License: http://github.com/tadvi/vichart/blob/master/LICENSE 
+//
+package vichart
+
+import (
+	"github.com/ajstarks/svgo"
+	"time"
+)
+
+// vbarPlot is plot of vertical bars with optional line shared by VBarChart
+// and VBMultiChart. It lays out gutters and draws titles, gridlines, X labels,
+// Y lines and legend around bars, charts draw only their bars. Plot is
+// created for every draw from chart fields.
+type vbarPlot struct {
+	canvas        *svg.SVG
+	width, height int
+	theme         *Theme
+	titles        chartTitles
+	layout        chartLayout // gutters and legend offset set on chart, zero if not set
+
+	count       int     // number of bars
+	barSpacing  int     // distance between bar starts, bars fill plot width if not set
+	barWidth    int     // bar width, computed from bar slot and padding if not set
+	padding     float64 // part of bar slot left empty between bars
+	labelsX     []string
+	labelFit    LabelFit
+	timesX      []time.Time
+	timeFormat  string
+	labelsY1    []string
+	labelsY2    []string
+	formatY1    Formatter
+	formatY2    Formatter
+	grid        Grid
+	valueLabels ValueLabel
+	lineValues  []float64
+	legend      []legendItem
+	scales      func(base, top float64) (bar, line Scale) // scales of bars and line between base and top
+
+	lineXYStyle    string
+	gridStyle      string
+	minorGridStyle string
+	lineStyle      string
+
+	// placed by start, bars are barWidth wide and grow from zero
+	offsets, centers []int // left X and center X of every bar
+	zero             int
+	barScale         Scale
+	lineScale        Scale
+
+	x, y, right, top int // plot edges, X line goes 12px below y
+	slot             float64
+	fit              labelFit
+	timeScale        TimeScale
+}
+
+// resolve returns gutters and legend offset, those that are not set on chart
+// are sized from labels, legend and titles, so chart fits its size without
+// manual tweaking.
+func (p *vbarPlot) resolve() chartLayout {
+	size := fontSize(p.theme)
+	area := p.titles.area(p.width, p.height)
+	barScale, lineScale := p.scales(1, 0)
+	l := p.layout
+	if l.gutterLeft == 0 {
+		_, labels := axisTicks(barScale, p.labelsY1, p.formatY1)
+		l.gutterLeft = yGutter(labels, size)
+	}
+	if l.gutterRight == 0 {
+		if len(p.timesX) > 0 {
+			_, labels := timeAxisTicks(p.newTimeScale(0, 1), p.timeFormat)
+			l.gutterRight = xLabelOverhang(labels, size)
+		} else {
+			// estimate slot from plot width without right gutter
+			slot := barSlot(area.right-area.left-l.gutterLeft-layoutPadding, p.count, p.barSpacing)
+			l.gutterRight = barLabelOverhang(p.labelsX, size, slot)
+		}
+		if p.rightLine() {
+			_, labels := axisTicks(lineScale, p.labelsY2, p.formatY2)
+			if gutter := yGutter(labels, size) - 4; gutter > l.gutterRight {
+				l.gutterRight = gutter
+			}
+		}
+	}
+	if l.gutterTop == 0 {
+		l.gutterTop = topGutter(len(p.legend) > 0, size) + valueHeight(p.valueLabels, size)
+	}
+	if l.legendX == 0 {
+		plotWidth := area.right - area.left - l.gutterLeft - l.gutterRight
+		l.legendX = centerLegend(p.legend, size, plotWidth)
+	}
+	return l
+}
+
+// start draws titles, gridlines and zero baseline and places bars. Chart
+// draws its bars after start and completes the plot with finish.
+func (p *vbarPlot) start() {
+	canvas := p.canvas
+	p.titles.draw(canvas, p.width, p.height)
+	area := p.titles.area(p.width, p.height)
+	p.layout = p.resolve()
+	p.x, p.right, p.top = area.left+p.layout.gutterLeft, area.right-p.layout.gutterRight, area.top
+	p.slot = barSlot(p.right-p.x, p.count, p.barSpacing)
+	if p.barWidth == 0 {
+		p.barWidth = barWidth(p.slot, p.padding)
+	}
+	// bars are centered at their time, half bar is kept free on both ends
+	p.timeScale = p.newTimeScale(p.x+p.barWidth/2, p.right-p.barWidth/2)
+	p.offsets = barOffsets(p.count, p.x, p.slot, p.barWidth, p.timeScale, p.timesX)
+	p.centers = make([]int, len(p.offsets))
+	for i, xoffset := range p.offsets {
+		p.centers[i] = xoffset + p.barWidth/2
+	}
+	// labels that do not fit take more space below bars
+	p.fit = fitLabels(p.categoryLabels(), fontSize(p.theme), labelSlot(p.centers, len(p.labelsX), p.slot),
+		p.labelFit, (area.bottom-area.top)/3)
+	p.y = area.bottom - p.fit.height
+	// bars grow up from y+3, value labels under negative bars are kept above X line
+	bar, _ := p.scales(1, 0)
+	base := p.y + 3 - valueDepth(p.valueLabels, fontSize(p.theme), bar.Min)
+	p.barScale, p.lineScale = p.scales(float64(base), float64(area.top+p.layout.gutterTop+3))
+
+	// gridlines go behind bars
+	gridX := labelCenters(p.centers, len(p.labelsX))
+	if len(p.timesX) > 0 {
+		gridX, _ = timeAxisTicks(p.timeScale, p.timeFormat)
+	}
+	gridY, _ := axisTicks(p.barScale, p.labelsY1, p.formatY1)
+	drawGrid(canvas, p.grid, gridX, gridY, p.x, int(p.barScale.To), p.right, int(p.barScale.From),
+		p.gridStyle, p.minorGridStyle)
+
+	// zero baseline when bars go below zero
+	p.zero = int(p.barScale.Base())
+	if p.barScale.Min < 0 {
+		canvas.Line(p.x, p.zero, p.right, p.zero, p.lineXYStyle)
+	}
+}
+
+// finish draws line over bars, X labels, Y lines and legend.
+func (p *vbarPlot) finish() {
+	canvas := p.canvas
+	for i := 1; i < len(p.lineValues); i++ {
+		y1 := int(p.lineScale.Map(p.lineValues[i-1]))
+		y2 := int(p.lineScale.Map(p.lineValues[i]))
+		canvas.Line(p.centers[i-1], y1, p.centers[i], y2, p.lineStyle)
+	}
+
+	// bottom line markers and labels
+	if len(p.timesX) > 0 {
+		pos, labels := timeAxisTicks(p.timeScale, p.timeFormat)
+		drawXLine(canvas, p.y+12, p.x, p.right, pos, labels, p.lineXYStyle)
+	} else {
+		drawBarLabels(canvas, p.y+12, p.x, p.right, p.centers, p.labelsX, p.fit, fontSize(p.theme), p.lineXYStyle)
+	}
+
+	// left vertical Y line
+	pos, labelsY := axisTicks(p.barScale, p.labelsY1, p.formatY1)
+	drawYLine(canvas, p.x, p.barScale, pos, p.lineXYStyle)
+	drawYLineText(canvas, p.x-16, pos, labelsY, true)
+	// right vertical Y line
+	if p.rightLine() {
+		xright := p.right + 12
+		pos, labelsY = axisTicks(p.lineScale, p.labelsY2, p.formatY2)
+		drawYLine(canvas, xright, p.lineScale, pos, p.lineXYStyle)
+		drawYLineText(canvas, xright, pos, labelsY, false)
+	}
+
+	drawLegend(canvas, p.x+p.layout.legendX, p.top, fontSize(p.theme), p.legend)
+}
+
+// categoryLabels returns category labels drawn under bars, bars placed by
+// time have time labels instead.
+func (p *vbarPlot) categoryLabels() []string {
+	if len(p.timesX) > 0 {
+		return nil
+	}
+	return p.labelsX
+}
+
+// rightLine reports if right Y line for line values is drawn.
+func (p *vbarPlot) rightLine() bool {
+	return len(p.lineValues) > 0 || len(p.labelsY2) > 0
+}
+
+// newTimeScale returns scale for times of bars between from and to.
+func (p *vbarPlot) newTimeScale(from, to int) TimeScale {
+	min, max := timeRange(p.timesX)
+	return NewTimeScale(min, max, float64(from), float64(to))
+}
@@ -7,249 +7,415 @@ package vichart
 import (
 	"fmt"
 	"github.com/ajstarks/svgo"
+	"time"
 )
 
 const (
+	VBMultiGroupPadding = 0.2
+	VBMultiInnerPadding = 0.1
+)
+
+// Deprecated: VBMultiChart takes its default styles from Theme, series
+// colors come from Theme palette.
+const (
+	VBMultiGstyle      = "font-family:Calibri; font-size:14"
 	VBMultiLineXYStyle = "stroke:lightgray;stroke-width:2px;"
 	VBMultiLineStyle   = "fill:navy;stroke:navy;stroke-width:2px;"
 	VBMultiBarStyle1   = "fill:green;stroke:gray;"
 	VBMultiBarStyle2   = "fill:yellow;stroke:gray;"
 	VBMultiBarStyle3   = "fill:white;stroke:gray;"
+	VBMultiBarStyle4   = "fill:navy;stroke:gray;"
+	VBMultiBarStyle5   = "fill:orange;stroke:gray;"
+	VBMultiBarStyle6   = "fill:teal;stroke:gray;"
+)
 
-	VBMultiGstyle      = "font-family:Calibri; font-size:14"
-	VBMultiGutterLeft  = 40
-	VBMultiGutterRight = 40
-	VBMultiGutterTop   = 40
-
-	VBMultiBarSpacing    = 16
-	VBMultiBarWidth      = 15
+// Deprecated: gutters and legend offset that are not set are sized from
+// labels, legend and chart size.
+const (
+	VBMultiGutterLeft    = 40
+	VBMultiGutterRight   = 40
+	VBMultiGutterTop     = 40
 	VBMultiLegendXOffset = 10
 )
 
+// Deprecated: bars fill plot width when BarSpacing and BarWidth are not set.
+const (
+	VBMultiBarSpacing = 16
+	VBMultiBarWidth   = 15
+)
+
 type VBMultiChart struct {
 	Svg           *svg.SVG
 	Width, Height int
-	BarValues     []VBMultiChartItem // chart bar values
-	LineValues    []int              // chart line values
-	MaxBarValue   int                // chart max value, used for scaling all the bar values
-	MaxLineValue  int                // chart max value, used for scaling all the line values
+	BarValues     []VBMultiChartItem // chart bar values, one value per series in every item
+	LineValues    []float64          // chart line values
+	MaxBarValue   float64            // chart max value, used for scaling all the bar values, computed from BarValues if not set
+	MaxLineValue  float64            // chart max value, used for scaling all the line values, computed from LineValues if not set
+	MinBarValue   float64            // chart min value for bars, computed from BarValues if not set
+	MinLineValue  float64            // chart min value for line, computed from LineValues if not set
 
 	// optional fields below
-	BarSpacing int
-	BarWidth   int
-	LabelsX    []string
-	LabelsY1   []string
-	LabelsY2   []string
+	BarSpacing int // distance between bar starts, bars fill plot width if not set
+	BarWidth   int // stacked bar width, computed from bar slot and GroupPadding if not set
+
+	// grouped mode draws series side by side within every category instead of
+	// stacking them, group width is computed from bar slot and GroupPadding,
+	// BarWidth is not used
+	Grouped      bool
+	GroupPadding float64     // part of category slot left empty between stacked bars or groups, VBMultiGroupPadding if not set, NoPadding for none
+	InnerPadding float64     // part of bar slot left empty between bars in group, VBMultiInnerPadding if not set, NoPadding for none
+	LabelsX      []string    // labels under bar centers, spread over bars if there are fewer labels than bars
+	LabelFit     LabelFit    // how LabelsX that do not fit next to each other are drawn
+	TimesX       []time.Time // optional time of every bar, bars are placed on time X line when set
+	TimeFormat   string      // time layout for X labels with TimesX, picked by tick interval if not set
+	LabelsY1     []string
+	LabelsY2     []string
+	FormatY1     Formatter // formats generated left Y line labels, with precision of tick step if not set
+	FormatY2     Formatter // formats generated right Y line labels
+	ScaleY1      ScaleType // scale of bars and left Y line, linear if not set
+	ScaleY2      ScaleType // scale of line and right Y line, linear if not set
+	Grid         Grid      // gridlines drawn behind bars, none if not set
+
+	// titles, plot area shrinks to make room for them
+	Title       string
+	Subtitle    string
+	XAxisTitle  string
+	YAxisTitle  string // title of left Y line
+	Y2AxisTitle string // title of right Y line
+	Caption     string // footnote at bottom left
+	Source      string // data source at bottom right
 
 	GutterLeft  int
 	GutterRight int // right gutter for the chart, used to fit last bottom label
 	GutterTop   int // top gutter for the chart, used top label
 
-	// styles
-	Gstyle      string
-	LineXYStyle string
-	LineStyle   string
-	BarStyle1   string
-	BarStyle2   string
-	BarStyle3   string
-
-	// legend
-	BarLegend1 string
-	BarLegend2 string
-	BarLegend3 string
+	// styles, taken from Theme if not set
+	Classes        bool   // emit class names styled by StyleSheet instead of inline styles, DrawAt expects the document to include it
+	StyleSheetURL  string // with Classes, Draw imports this CSS instead of embedding StyleSheet of Theme
+	Theme          *Theme // DefaultTheme is used if not set
+	Gstyle         string
+	LineXYStyle    string
+	GridStyle      string
+	MinorGridStyle string
+	LineStyle      string
+	ValueStyle     string
+
+	// bar series from bottom to top of the stack, default styles are used
+	// when not set, legend entry is drawn for every named series
+	Series     []BarSeries
 	LineLegend string
 
+	// value labels, not written if not set. Stacked bars have label inside
+	// of every segment or label of the stack total outside.
+	ValueLabels ValueLabel
+	ValueFormat Formatter // formats value labels, FormatY1 or shortest decimal form if not set
+
 	// legend offset
 	LegendXOffset int
 }
 
-type VBMultiChartItem struct {
-	Bottom, Middle, Top int
+// VBMultiChartItem is single stacked bar with one value per series from bottom
+// to top. Positive values are stacked up from zero, negative values are stacked
+// down from zero.
+type VBMultiChartItem []float64
+
+// BarSeries is single named layer of stacked bars.
+type BarSeries struct {
+	Name  string
+	Style string // bar style, theme palette is cycled if not set
 }
 
-// Draw produces chart on screen, main entry point.
-func (chart *VBMultiChart) Draw() error {
-	canvas := chart.Svg
-	if chart.Svg == nil {
-		return fmt.Errorf("Missing pointer to svg.SVG in field Svg.")
-	}
-	if chart.Width < 10 || chart.Height < 10 {
-		return fmt.Errorf("Incorrect Width or Height value.")
+// Validate checks that all required chart fields are set.
+func (chart *VBMultiChart) Validate() error {
+	if err := validateCanvas(chart.Svg, chart.Width, chart.Height); err != nil {
+		return err
 	}
 	if len(chart.BarValues) == 0 {
 		return fmt.Errorf("Missing BarValues for the chart.")
 	}
-	if chart.MaxBarValue == 0 {
-		return fmt.Errorf("Missing chart MaxBarValue.")
+	if len(chart.LineValues) > 0 && len(chart.BarValues) != len(chart.LineValues) {
+		return fmt.Errorf("Number of BarValues does not match number of LineValues.")
 	}
-	if chart.MaxLineValue == 0 && len(chart.LineValues) > 0 {
-		return fmt.Errorf("Missing chart MaxLineValue.")
+	if len(chart.TimesX) > 0 && len(chart.BarValues) != len(chart.TimesX) {
+		return fmt.Errorf("Number of BarValues does not match number of TimesX.")
 	}
-	if len(chart.BarValues) != len(chart.LineValues) {
-		return fmt.Errorf("Number of BarValues does not match number of LineValues.")
+	for i, item := range chart.BarValues {
+		if len(chart.Series) > 0 && len(item) != len(chart.Series) {
+			return fmt.Errorf("Number of values in BarValues item %d does not match number of Series.", i)
+		}
+		if err := validateValues(fmt.Sprintf("BarValues item %d", i), item); err != nil {
+			return err
+		}
+	}
+	if err := validateValues("LineValues", chart.LineValues); err != nil {
+		return err
+	}
+	if !finite(chart.MinBarValue, chart.MaxBarValue, chart.MinLineValue, chart.MaxLineValue) {
+		return fmt.Errorf("Min and max values must be finite numbers.")
+	}
+	for _, item := range chart.BarValues {
+		if err := validateScale(chart.ScaleY1, "BarValues", chart.MinBarValue, item); err != nil {
+			return err
+		}
 	}
-	// default to sensible constants if value is not set
+	if err := validateScale(chart.ScaleY2, "LineValues", chart.MinLineValue, chart.LineValues); err != nil {
+		return err
+	}
+	bar, line := chart.scales(1, 0)
+	if err := validateDomain("BarValues", bar); err != nil {
+		return err
+	}
+	if len(chart.LineValues) > 0 || len(chart.LabelsY2) > 0 {
+		return validateDomain("LineValues", line)
+	}
+	return nil
+}
+
+// SetDefaults sets sensible constants for optional fields that are not set.
+func (chart *VBMultiChart) SetDefaults() {
+	theme := stylesOf(chart.Theme, chart.Classes)
 	if chart.LineXYStyle == "" {
-		chart.LineXYStyle = VBMultiLineXYStyle
+		chart.LineXYStyle = theme.AxisStyle()
 	}
-	if chart.Gstyle == "" {
-		chart.Gstyle = VBMultiGstyle
+	if chart.GridStyle == "" {
+		chart.GridStyle = theme.GridStyle()
 	}
-	if chart.LineStyle == "" {
-		chart.LineStyle = VBMultiLineStyle
+	if chart.MinorGridStyle == "" {
+		chart.MinorGridStyle = theme.MinorGridStyle()
+	}
+	if chart.Gstyle == "" {
+		chart.Gstyle = theme.Gstyle()
 	}
-	if chart.BarStyle1 == "" {
-		chart.BarStyle1 = VBMultiBarStyle1
+	// add unnamed series for items that have more values than series
+	for _, item := range chart.BarValues {
+		for len(chart.Series) < len(item) {
+			chart.Series = append(chart.Series, BarSeries{})
+		}
 	}
-	if chart.BarStyle2 == "" {
-		chart.BarStyle2 = VBMultiBarStyle2
+	for i := range chart.Series {
+		if chart.Series[i].Style == "" {
+			chart.Series[i].Style = theme.FillStyle(i)
+		}
 	}
-	if chart.BarStyle3 == "" {
-		chart.BarStyle3 = VBMultiBarStyle3
+	if chart.LineStyle == "" {
+		// line gets first color not used by bars
+		chart.LineStyle = theme.LineStyle(len(chart.Series))
 	}
-	if chart.GutterLeft == 0 {
-		chart.GutterLeft = VBMultiGutterLeft
+	if chart.ValueLabels == ValueLabelDefault {
+		chart.ValueLabels = ValueLabelNone
 	}
-	if chart.GutterRight == 0 {
-		chart.GutterRight = VBMultiGutterRight
+	if chart.ValueFormat == nil {
+		chart.ValueFormat = chart.FormatY1
 	}
-	if chart.GutterTop == 0 {
-		chart.GutterTop = VBMultiGutterTop
+	if chart.ValueFormat == nil {
+		chart.ValueFormat = formatValue
 	}
-	if chart.BarSpacing == 0 {
-		chart.BarSpacing = VBMultiBarSpacing
+	if chart.ValueStyle == "" {
+		chart.ValueStyle = ValueStyle
 	}
-	if chart.BarWidth == 0 {
-		chart.BarWidth = VBMultiBarWidth
+	if chart.GroupPadding == 0 {
+		chart.GroupPadding = VBMultiGroupPadding
 	}
-	if chart.LegendXOffset == 0 {
-		chart.LegendXOffset = VBMultiLegendXOffset
+	if chart.InnerPadding == 0 {
+		chart.InnerPadding = VBMultiInnerPadding
 	}
+}
 
-	// start SVG
-	canvas.Start(chart.Width, chart.Height)
-	canvas.Gstyle(chart.Gstyle)
-	x, y := chart.GutterLeft, chart.Height-42
-	bHeight := float64(y - chart.GutterTop)
-	bWidth := float64(chart.Width - chart.GutterRight - x)
+// scales returns scales of bars and line between base and top.
+func (chart *VBMultiChart) scales(base, top float64) (bar, line Scale) {
+	bar = axisScale(chart.ScaleY1, chart.MinBarValue, chart.MaxBarValue, chart.barValues(), base, top)
+	line = axisScale(chart.ScaleY2, chart.MinLineValue, chart.MaxLineValue, chart.LineValues, base, top)
+	return bar, line
+}
 
-	xoffset := x
-	for i, _ := range chart.BarValues {
-		yoffset := y + 3
-		// scale value to fit in chart pixels
-		chartVal := chart.calcBarValue(bHeight, chart.BarValues[i].Bottom)
-		chart.drawMeter(xoffset, yoffset, chart.BarWidth, chartVal, chart.BarStyle1)
-		yoffset -= chartVal
+// Draw produces chart on screen, main entry point.
+func (chart *VBMultiChart) Draw() error {
+	return drawChart(chart)
+}
 
-		chartVal = chart.calcBarValue(bHeight, chart.BarValues[i].Middle)
-		chart.drawMeter(xoffset, yoffset, chart.BarWidth, chartVal, chart.BarStyle2)
-		yoffset -= chartVal
+// DrawAt draws chart into its own viewport at x, y of existing SVG document
+// without starting or ending it.
+func (chart *VBMultiChart) DrawAt(x, y int) error {
+	return drawChartAt(chart, x, y, false)
+}
 
-		chartVal = chart.calcBarValue(bHeight, chart.BarValues[i].Top)
-		chart.drawMeter(xoffset, yoffset, chart.BarWidth, chartVal, chart.BarStyle3)
-		yoffset -= chartVal
+// SetCanvas points chart to canvas and sets chart size.
+func (chart *VBMultiChart) SetCanvas(canvas *svg.SVG, width, height int) {
+	chart.Svg = canvas
+	chart.Width, chart.Height = width, height
+}
 
-		// draw line on the chart
-		if i > 0 && len(chart.LineValues) > 0 {
-			valLine1 := float64(chart.LineValues[i-1])
-			chartValLine1 := int((valLine1 / float64(chart.MaxLineValue)) * bHeight)
-			valLine2 := float64(chart.LineValues[i])
-			chartValLine2 := int((valLine2 / float64(chart.MaxLineValue)) * bHeight)
+// frame returns canvas of the chart and styles of its document.
+func (chart *VBMultiChart) frame() chartFrame {
+	return chartFrame{chart.Svg, chart.Width, chart.Height, chart.Gstyle, chart.Theme, chart.Classes,
+		chart.StyleSheetURL}
+}
 
-			xpos := xoffset + chart.BarWidth/2
-			canvas.Line(xpos-chart.BarSpacing, y-chartValLine1+3, xpos, y-chartValLine2+3, chart.LineStyle)
+// seriesCount returns number of series styled by style sheet, items may have
+// more values than named series and line gets color after the bars.
+func (chart *VBMultiChart) seriesCount() int {
+	n := len(chart.Series)
+	for _, item := range chart.BarValues {
+		if len(item) > n {
+			n = len(item)
 		}
-		xoffset += chart.BarSpacing
-	}
-
-	// bottom line markers and labels
-	canvas.Line(x, y+12, chart.Width-chart.GutterRight, y+12, chart.LineXYStyle)
-	labels := len(chart.LabelsX)
-	// display bottom line labels
-	for i := 0; i < labels; i++ {
-		step := bWidth / float64(labels-1)
-		xoffset := int(float64(i) * step)
-		canvas.Text(x+xoffset, y+30, chart.LabelsX[i], "font-size:75%;text-anchor:middle;")
-		canvas.Line(x+xoffset, y+6, x+xoffset, y+18, chart.LineXYStyle)
 	}
+	return n + 1
+}
 
-	// left vertical Y line
-	chart.drawYLine(x, y+2)
-	chart.drawYLineText(x-16, y, chart.LabelsY1, true)
-	// right vertical Y line
-	chart.drawYLine(chart.Width-chart.GutterRight+12, y+2)
-	chart.drawYLineText(chart.Width-chart.GutterRight+12, y, chart.LabelsY2, false)
-
-	chart.drawLegend(x)
+// copy returns copy of chart for single draw, classes turns on class names.
+// Series are copied too as SetDefaults fills in their styles.
+func (chart *VBMultiChart) copy(classes bool) drawer {
+	c := *chart
+	c.Classes = c.Classes || classes
+	c.Series = append(c.Series[:0:0], c.Series...)
+	return &c
+}
 
-	canvas.Gend()
-	canvas.End()
+// draw renders chart body.
+func (chart *VBMultiChart) draw() error {
+	p := chart.plot()
+	p.start()
+	for i, item := range chart.BarValues {
+		if chart.Grouped {
+			chart.drawGroup(p.offsets[i], p.zero, p.barWidth, item, p.barScale)
+		} else {
+			chart.drawStack(p.offsets[i], p.zero, p.barWidth, item, p.barScale)
+		}
+	}
+	p.finish()
 	return nil
 }
 
-func (chart *VBMultiChart) calcBarValue(bHeight float64, value int) int {
-	val := float64(value)
-	chartVal := int((val / float64(chart.MaxBarValue)) * bHeight)
-	return chartVal
+// plot returns plot of bars, axes and line of the chart. Bar width is width
+// of single stacked bar or of group of bars side by side.
+func (chart *VBMultiChart) plot() *vbarPlot {
+	width := chart.BarWidth
+	if chart.Grouped {
+		width = 0
+	}
+	return &vbarPlot{
+		canvas: chart.Svg, width: chart.Width, height: chart.Height, theme: chart.Theme, titles: chart.titles(),
+		layout: chartLayout{gutterLeft: chart.GutterLeft, gutterRight: chart.GutterRight, gutterTop: chart.GutterTop,
+			legendX: chart.LegendXOffset},
+		count: len(chart.BarValues), barSpacing: chart.BarSpacing, barWidth: width, padding: chart.GroupPadding,
+		labelsX: chart.LabelsX, labelFit: chart.LabelFit, timesX: chart.TimesX, timeFormat: chart.TimeFormat,
+		labelsY1: chart.LabelsY1, labelsY2: chart.LabelsY2, formatY1: chart.FormatY1, formatY2: chart.FormatY2,
+		grid: chart.Grid, valueLabels: chart.ValueLabels, lineValues: chart.LineValues,
+		legend: chart.legendItems(), scales: chart.scales,
+		lineXYStyle: chart.LineXYStyle, gridStyle: chart.GridStyle, minorGridStyle: chart.MinorGridStyle,
+		lineStyle: chart.LineStyle,
+	}
 }
 
-// drawLegend produces legend on the chart.
-func (chart *VBMultiChart) drawLegend(x int) {
-	canvas := chart.Svg
-	canvas.Rect(x+chart.LegendXOffset, 10, 40, 10, chart.BarStyle1)
-	canvas.Text(x+chart.LegendXOffset+50, 20, chart.BarLegend1, "font-size:75%;")
+// titles returns chart titles.
+func (chart *VBMultiChart) titles() chartTitles {
+	return chartTitles{chart.Title, chart.Subtitle, chart.XAxisTitle, chart.YAxisTitle,
+		chart.Y2AxisTitle, chart.Caption, chart.Source}
+}
 
-	canvas.Rect(x+chart.LegendXOffset+120, 10, 40, 10, chart.BarStyle2)
-	canvas.Text(x+chart.LegendXOffset+170, 20, chart.BarLegend2, "font-size:75%;")
+// calcBarValue scales value into signed bar height in pixels.
+func (chart *VBMultiChart) calcBarValue(scale Scale, value float64) int {
+	return int(scale.Base()) - int(scale.barEnd(value))
+}
 
-	canvas.Rect(x+chart.LegendXOffset+230, 10, 40, 10, chart.BarStyle3)
-	canvas.Text(x+chart.LegendXOffset+280, 20, chart.BarLegend3, "font-size:75%;")
+// drawStack draws values of single item stacked on top of each other at x,
+// bars are width pixels wide.
+func (chart *VBMultiChart) drawStack(x, zero, width int, item VBMultiChartItem, scale Scale) {
+	up, down := zero, zero
+	positive, negative := 0.0, 0.0
+	for j, val := range item {
+		// segment ends where running sum ends, so stack is not distorted
+		// by non-linear scale
+		var base, chartVal int
+		if val < 0 {
+			negative += val
+			base, chartVal = down, down-int(scale.barEnd(negative))
+			down -= chartVal
+		} else {
+			positive += val
+			base, chartVal = up, up-int(scale.barEnd(positive))
+			up -= chartVal
+		}
+		chart.drawMeter(x, base, width, chartVal, chart.Series[j].Style)
+		if chart.ValueLabels != ValueLabelNone && chart.ValueLabels != ValueLabelOutside {
+			chart.drawValue(x, base, width, chartVal, val)
+		}
+	}
+	// totals go past both ends of the stack
+	if chart.ValueLabels == ValueLabelOutside {
+		if up < zero {
+			chart.drawValue(x, zero, width, zero-up, positive)
+		}
+		if down > zero {
+			chart.drawValue(x, zero, width, zero-down, negative)
+		}
+	}
+}
 
-	if chart.LineLegend != "" {
-		xpos := x + chart.LegendXOffset + 340
-		canvas.Line(xpos, 15, xpos+40, 15, chart.LineStyle)
-		canvas.Text(x+chart.LegendXOffset+390, 20, chart.LineLegend, "font-size:75%;")
+// drawGroup draws values of single item side by side within width starting at x.
+func (chart *VBMultiChart) drawGroup(x, zero, width int, item VBMultiChartItem, scale Scale) {
+	barSlot := float64(width) / float64(len(chart.Series))
+	w := barWidth(barSlot, chart.InnerPadding)
+	for j, val := range item {
+		xoffset := x + int(float64(j)*barSlot+(barSlot-float64(w))/2)
+		chartVal := chart.calcBarValue(scale, val)
+		chart.drawMeter(xoffset, zero, w, chartVal, chart.Series[j].Style)
+		if chart.ValueLabels != ValueLabelNone {
+			chart.drawValue(xoffset, zero, w, chartVal, val)
+		}
 	}
 }
 
-// drawYLineText draws Y line text.
-func (chart *VBMultiChart) drawYLineText(x, h int, labels []string, left bool) {
-	canvas := chart.Svg
+// drawValue writes value label of bar at x, y of width w and signed height h.
+func (chart *VBMultiChart) drawValue(x, y, w, h int, value float64) {
+	drawVBarValue(chart.Svg, x, y, w, h, chart.ValueFormat(value), chart.ValueLabels,
+		fontSize(chart.Theme), chart.ValueStyle)
+}
 
-	style := "font-size:75%;text-anchor:start;baseline-shift:-75%"
-	if left {
-		style = "font-size:75%;text-anchor:end;baseline-shift:-75%"
-	}
-	labelsCount := len(labels)
-	for i := 0; i < labelsCount; i++ {
-		step := float64(h-chart.GutterTop) / float64(labelsCount-1)
-		yoffset := int(float64(i) * step)
-		canvas.Text(x, yoffset+chart.GutterTop, labels[labelsCount-i-1], style)
+// barValues returns all bar values, stacked bars also return sums of their
+// positive and negative values they reach.
+func (chart *VBMultiChart) barValues() []float64 {
+	var values []float64
+	for _, item := range chart.BarValues {
+		values = append(values, item...)
+		if chart.Grouped {
+			continue
+		}
+		up, down := 0.0, 0.0
+		for _, val := range item {
+			if val < 0 {
+				down += val
+			} else {
+				up += val
+			}
+		}
+		values = append(values, up, down)
 	}
+	return values
 }
 
-// drawYLine draws Y line on screen.
-func (chart *VBMultiChart) drawYLine(x, y int) {
-	canvas := chart.Svg
-	canvas.Line(x-8, chart.GutterTop, x-8, y, chart.LineXYStyle)
-
-	height := float64(y - chart.GutterTop)
-	step := height / 10
-	pos := 0
-	for i := 0.0; i <= height; i += step {
-		marker := int(height-i) + 1 + chart.GutterTop
-		if pos == 0 || pos == 5 || pos == 10 {
-			canvas.Line(x-2, marker, x-14, marker, chart.LineXYStyle)
-		} else {
-			canvas.Line(x-5, marker, x-11, marker, chart.LineXYStyle)
+// legendItems returns legend entries for named series and line with legend set.
+func (chart *VBMultiChart) legendItems() []legendItem {
+	var items []legendItem
+	for _, series := range chart.Series {
+		if series.Name != "" {
+			items = append(items, legendItem{label: series.Name, style: series.Style})
 		}
-		pos += 1
 	}
+	if chart.LineLegend != "" {
+		items = append(items, legendItem{label: chart.LineLegend, style: chart.LineStyle, line: true})
+	}
+	return items
 }
 
-// drawMeter draws bar on chart.
+// drawMeter draws bar on chart, negative values grow down from y.
 func (chart *VBMultiChart) drawMeter(x, y, w, value int, barStyle string) {
 	canvas := chart.Svg
 	corner := w
+	if value < 0 {
+		canvas.Roundrect(x, y, corner, -value, 0, 0, barStyle)
+		return
+	}
 	canvas.Roundrect(x, y-value, corner, value, 0, 0, barStyle)
 }
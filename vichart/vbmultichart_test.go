@@ -0,0 +1,28 @@
+// ViChart library for Go
+// Author: Tad Vizbaras 
+// License: http://github.com/tadvi/vichart/blob/master/LICENSE 
+//
+package vichart
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/ajstarks/svgo"
+)
+
+func TestVBMultiNoPadding(t *testing.T) {
+	var buf bytes.Buffer
+	chart := VBMultiChart{Svg: svg.New(&buf), Width: 400, Height: 300, Grouped: true,
+		BarValues: []VBMultiChartItem{{1, 2}, {3, 4}}, GroupPadding: NoPadding, InnerPadding: NoPadding}
+	bars := named(render(t, &chart, &buf), "rect")
+	if len(bars) != 4 {
+		t.Fatalf("draws %d bars, want 4", len(bars))
+	}
+	for i := 1; i < len(bars); i++ {
+		gap := attr(t, bars[i], "x") - attr(t, bars[i-1], "x") - attr(t, bars[i-1], "width")
+		if gap > 1 {
+			t.Errorf("gap before bar %d is %d, want bars touching", i, gap)
+		}
+	}
+}
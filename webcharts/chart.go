@@ -3,18 +3,30 @@ package main
 import (
 	"github.com/ajstarks/svgo"
 	"log"
+	"math"
 	"math/rand"
 	"net/http"
 	"time"
 	"vichart"
+	"vichart/palette"
 	"strconv"
 )
 
 func main() {
 	http.Handle("/piechart", http.HandlerFunc(piechart))
+	http.Handle("/donutchart", http.HandlerFunc(donutchart))
 	http.Handle("/hchart", http.HandlerFunc(hchart))
 	http.Handle("/vchart", http.HandlerFunc(vchart))
 	http.Handle("/vbmultichart", http.HandlerFunc(vbmultichart))
+	http.Handle("/groupchart", http.HandlerFunc(groupchart))
+	http.Handle("/labelchart", http.HandlerFunc(labelchart))
+	http.Handle("/linechart", http.HandlerFunc(linechart))
+	http.Handle("/timechart", http.HandlerFunc(timechart))
+	http.Handle("/logchart", http.HandlerFunc(logchart))
+	http.Handle("/combined", http.HandlerFunc(combined))
+	http.Handle("/classchart", http.HandlerFunc(classchart))
+	http.Handle("/themes", http.HandlerFunc(themes))
+	http.Handle("/dashboard", http.HandlerFunc(dashboard))
 	err := http.ListenAndServe(":8080", nil)
 	if err != nil {
 		log.Fatal("ListenAndServe:", err)
@@ -31,22 +43,51 @@ func piechart(w http.ResponseWriter, req *http.Request) {
 		Svg:	canvas,
 		Width:	650,
 		Height: 400,
-		PieValues: []int{},
+		PieValues: []float64{},
 		Labels: []string{},		
-		LegendXOffset: 250,
-		GutterLeft: 40,
+		LegendXOffset: 300,
+		GutterLeft: 80,
 		GutterTop: 40,
+		SliceLabels: vichart.SliceLabelPercent,
+		Sort: true,
+		StartAngle: vichart.PieStartTop,
+		OtherThreshold: 5,
 	}
 	
 	for i:=0; i < 8; i++ {
 		val := rand.Intn(100)
-		chart.PieValues = append(chart.PieValues, val)
+		chart.PieValues = append(chart.PieValues, float64(val))
 		chart.Labels = append(chart.Labels, strconv.Itoa(val))		
 	}
 	vichart.Must(chart.Draw())	
 	
 }
 
+// donutchart draws piechart with hole and total in the center.
+func donutchart(w http.ResponseWriter, req *http.Request) {
+	w.Header().Set("Content-Type", "image/svg+xml")
+	canvas := svg.New(w)
+
+	chart := vichart.PieChart{
+		Svg:	canvas,
+		Width:	650,
+		Height: 400,
+		PieValues: []float64{4200, 2600, 1300, 700},
+		Labels: []string{"Search", "Direct", "Social", "Email"},
+		LegendXOffset: 250,
+		GutterLeft: 40,
+		GutterTop: 40,
+		InnerRadius: 50,
+		CenterTotal: true,
+		CenterLabel: []string{"visits"},
+		FillStyles: palette.Viridis.Colors(4).FillStyles("white"),
+		SliceLabels: vichart.SliceLabelValue,
+		LegendValues: vichart.SliceLabelPercent,
+		Exploded: []int{2},
+	}
+	vichart.Must(chart.Draw())
+}
+
 // vchart draws bar and line chart.
 func vchart(w http.ResponseWriter, req *http.Request) {
 	w.Header().Set("Content-Type", "image/svg+xml")
@@ -57,24 +98,25 @@ func vchart(w http.ResponseWriter, req *http.Request) {
 		Svg:           canvas,
 		Width:         650,
 		Height:        400,
-		LabelsY1:      []string{"Zero", "Half", "Full"},    // bar labels
-		LabelsY2:      []string{"Zero2", "Half2", "Full2"}, // line labels
 		LabelsX:       []string{"0", "1/4", "1/2", "3/4", "1"},
-		BarValues:     []int{},
-		LineValues:    []int{},
-		MaxBarValue:   3000,
-		MaxLineValue:  3000,
+		BarValues:     []float64{},
+		LineValues:    []float64{},
 		BarLegend:     "Speed",
 		LineLegend:    "Rpm",
-		BarWidth:      14,
-		BarSpacing:    18,  // should be greater than BarWidth
 		LegendXOffset: 100, // legend offset from the left
 		GutterRight:   60,
 		GutterLeft:    65,
+		Title:         "Engine test",
+		Subtitle:      "Speed and rpm per run",
+		XAxisTitle:    "Run",
+		YAxisTitle:    "Speed",
+		Y2AxisTitle:   "Rpm",
+		Source:        "Source: test bench",
+		Grid:          vichart.GridY | vichart.GridMinorY,
 	}
 	// populate chart with data
 	for i := 0; i < 12; i++ {
-		val := rand.Intn(chart.MaxBarValue)
+		val := float64(rand.Intn(3000))
 		chart.BarValues = append(chart.BarValues, val)
 		chart.LineValues = append(chart.LineValues, val)
 	}
@@ -93,35 +135,197 @@ func vbmultichart(w http.ResponseWriter, req *http.Request) {
 		Svg:          canvas,
 		Width:        572,
 		Height:       400,
-		LabelsY1:     []string{"0", "50", "100"},
-		LabelsY2:     []string{"0", "500", "1000"},
 		LabelsX:      []string{"0", "1", "2", "3", "4", "5", "6", "7", "8", "9", "10", "11", "12"},
 		BarValues:    []vichart.VBMultiChartItem{},
-		LineValues:   []int{},
-		MaxBarValue:  3000,
-		MaxLineValue: 3000,
-		BarWidth:     35,
-		BarSpacing:   39,
-		BarLegend1:   "Driving",
-		BarLegend2:   "Idle",
-		BarLegend3:   "Off",
+		LineValues:   []float64{},
+		Series: []vichart.BarSeries{
+			{Name: "Driving"},
+			{Name: "Idle"},
+			{Name: "Off"},
+			{Name: "Service"},
+		},
 		LineLegend:   "Distance",
+		ValueLabels:  vichart.ValueLabelCenter,
+		FormatY1:     vichart.SI(1),
 		GutterRight:  60,
 		GutterLeft:   45,
 	}
 	// populate chart with data
 	for i := 0; i < 12; i++ {
-		val1 := rand.Intn(chart.MaxBarValue / 2)
-		val2 := rand.Intn(chart.MaxBarValue / 3)
-		val3 := 3000 - val1 - val2
+		val1 := float64(rand.Intn(3000 / 2))
+		val2 := float64(rand.Intn(3000 / 3))
+		val3 := float64(rand.Intn(3000 / 6))
+		val4 := 3000 - val1 - val2 - val3
 
-		chart.BarValues = append(chart.BarValues, vichart.VBMultiChartItem{val1, val2, val3})
+		chart.BarValues = append(chart.BarValues, vichart.VBMultiChartItem{val1, val2, val3, val4})
 		chart.LineValues = append(chart.LineValues, val1*2)
 	}
 
 	vichart.Must(chart.Draw())
 }
 
+// groupchart draws grouped bar chart comparing two years.
+func groupchart(w http.ResponseWriter, req *http.Request) {
+	w.Header().Set("Content-Type", "image/svg+xml")
+	canvas := svg.New(w)
+	rand.Seed(int64(time.Now().Second()))
+
+	chart := vichart.VBMultiChart{
+		Svg:         canvas,
+		Width:       650,
+		Height:      400,
+		LabelsX:     []string{"Jan", "Feb", "Mar", "Apr", "May", "Jun", "Jul", "Aug", "Sep", "Oct", "Nov", "Dec"},
+		BarValues:   []vichart.VBMultiChartItem{},
+		Grouped:     true,
+		Series:      []vichart.BarSeries{{Name: "Last year"}, {Name: "This year"}},
+		GutterLeft:  50,
+		GutterRight: 20,
+	}
+	for i := 0; i < 12; i++ {
+		chart.BarValues = append(chart.BarValues,
+			vichart.VBMultiChartItem{float64(rand.Intn(3000)), float64(rand.Intn(3000))})
+	}
+
+	vichart.Must(chart.Draw())
+}
+
+// labelchart draws bar chart with long category labels, labels are wrapped,
+// rotated or thinned to fit.
+func labelchart(w http.ResponseWriter, req *http.Request) {
+	w.Header().Set("Content-Type", "image/svg+xml")
+	canvas := svg.New(w)
+	rand.Seed(int64(time.Now().Second()))
+
+	chart := vichart.VBarChart{
+		Svg:    canvas,
+		Width:  650,
+		Height: 400,
+		LabelsX: []string{"United Kingdom", "Germany", "France", "Netherlands", "Czech Republic",
+			"Lithuania", "New Zealand", "South Africa", "United States", "Argentina"},
+		BarValues:   []float64{},
+		Title:       "Visitors by country",
+		ValueLabels: vichart.ValueLabelOutside,
+	}
+	for i := 0; i < len(chart.LabelsX); i++ {
+		chart.BarValues = append(chart.BarValues, float64(rand.Intn(3000)))
+	}
+
+	vichart.Must(chart.Draw())
+}
+
+// linechart draws line chart with several series.
+func linechart(w http.ResponseWriter, req *http.Request) {
+	w.Header().Set("Content-Type", "image/svg+xml")
+	canvas := svg.New(w)
+	rand.Seed(int64(time.Now().Second()))
+
+	chart := vichart.LineChart{
+		Svg:     canvas,
+		Width:   650,
+		Height:  400,
+		LabelsX: []string{"Jan", "Feb", "Mar", "Apr", "May", "Jun", "Jul", "Aug", "Sep", "Oct", "Nov", "Dec"},
+		Series: []vichart.LineSeries{
+			{Name: "North", Marker: vichart.MarkerCircle},
+			{Name: "South", Marker: vichart.MarkerSquare},
+			{Name: "West"},
+		},
+		GutterLeft: 50,
+		Title:      "Sales by region",
+		YAxisTitle: "Units",
+		Caption:    "Negative values are returns.",
+		Grid:       vichart.GridBoth,
+		FormatY:    vichart.Thousands(0),
+	}
+	for i := range chart.Series {
+		for j := 0; j < 12; j++ {
+			chart.Series[i].Values = append(chart.Series[i].Values, float64(rand.Intn(3000)-500))
+		}
+	}
+
+	vichart.Must(chart.Draw())
+}
+
+// logchart draws line chart of values spanning several orders of magnitude on log scale.
+func logchart(w http.ResponseWriter, req *http.Request) {
+	w.Header().Set("Content-Type", "image/svg+xml")
+	canvas := svg.New(w)
+	rand.Seed(int64(time.Now().Second()))
+
+	chart := vichart.LineChart{
+		Svg:     canvas,
+		Width:   650,
+		Height:  400,
+		LabelsX: []string{"Mon", "Tue", "Wed", "Thu", "Fri", "Sat", "Sun"},
+		Series: []vichart.LineSeries{
+			{Name: "Median", Marker: vichart.MarkerCircle},
+			{Name: "p99", Marker: vichart.MarkerSquare},
+			{Name: "Max"},
+		},
+		Title:      "Request latency",
+		YAxisTitle: "Milliseconds",
+		Grid:       vichart.GridY | vichart.GridMinorY,
+		ScaleY:     vichart.ScaleLog10,
+	}
+	for i := range chart.Series {
+		for j := 0; j < 7; j++ {
+			chart.Series[i].Values = append(chart.Series[i].Values, float64(2+rand.Intn(8))*math.Pow(20, float64(i)))
+		}
+	}
+
+	vichart.Must(chart.Draw())
+}
+
+// classchart draws line chart styled by CSS classes, it follows dark mode of the browser.
+func classchart(w http.ResponseWriter, req *http.Request) {
+	w.Header().Set("Content-Type", "image/svg+xml")
+	canvas := svg.New(w)
+	rand.Seed(int64(time.Now().Second()))
+
+	chart := vichart.LineChart{
+		Svg:     canvas,
+		Width:   650,
+		Height:  400,
+		Classes: true,
+		Series: []vichart.LineSeries{
+			{Name: "North", Marker: vichart.MarkerCircle},
+			{Name: "South", Marker: vichart.MarkerSquare},
+		},
+	}
+	for i := range chart.Series {
+		for j := 0; j < 12; j++ {
+			chart.Series[i].Values = append(chart.Series[i].Values, float64(rand.Intn(3000)))
+		}
+	}
+
+	canvas.Start(chart.Width, chart.Height)
+	canvas.Style("text/css", vichart.AdaptiveStyleSheet(&vichart.LightTheme, &vichart.DarkTheme, len(chart.Series)))
+	vichart.Must(chart.DrawAt(0, 0))
+	canvas.End()
+}
+
+// timechart draws line chart with irregularly timestamped points.
+func timechart(w http.ResponseWriter, req *http.Request) {
+	w.Header().Set("Content-Type", "image/svg+xml")
+	canvas := svg.New(w)
+	rand.Seed(int64(time.Now().Second()))
+
+	chart := vichart.LineChart{
+		Svg:        canvas,
+		Width:      650,
+		Height:     400,
+		Series:     []vichart.LineSeries{{Name: "Requests", Marker: vichart.MarkerCircle}},
+		GutterLeft: 50,
+	}
+	t := time.Now().Add(-10 * 24 * time.Hour)
+	for t.Before(time.Now()) {
+		chart.Series[0].Times = append(chart.Series[0].Times, t)
+		chart.Series[0].Values = append(chart.Series[0].Values, float64(rand.Intn(3000)))
+		t = t.Add(time.Duration(rand.Intn(24)+1) * time.Hour)
+	}
+
+	vichart.Must(chart.Draw())
+}
+
 // hchart draws horizontal chart.
 func hchart(w http.ResponseWriter, req *http.Request) {
 	w.Header().Set("Content-Type", "image/svg+xml")
@@ -134,13 +338,121 @@ func hchart(w http.ResponseWriter, req *http.Request) {
 		Height:  200,
 		LabelsY: []string{"Cost", "Priorities", "Timing", "Technology"},
 		//Spacing: 18,
-		LabelsX:   []string{"0", "1/4", "1/2", "3/4", "3000"},
-		BarValues: []int{},
-		MaxValue:  3000,
+		BarValues: []float64{},
+		Grid:      vichart.GridX,
 	}
 	for i := 0; i < len(chart.LabelsY); i++ {
-		chart.BarValues = append(chart.BarValues, rand.Intn(chart.MaxValue))
+		// values might be negative, bars grow left from zero
+		chart.BarValues = append(chart.BarValues, float64(rand.Intn(3000)-1000))
 	}
 
 	vichart.Must(chart.Draw())
 }
+
+// combined draws two charts side by side in single SVG document.
+func combined(w http.ResponseWriter, req *http.Request) {
+	w.Header().Set("Content-Type", "image/svg+xml")
+	canvas := svg.New(w)
+	rand.Seed(int64(time.Now().Second()))
+
+	bars := vichart.HBarChart{
+		Svg:       canvas,
+		Width:     550,
+		Height:    200,
+		LabelsY:   []string{"Cost", "Priorities", "Timing", "Technology"},
+		BarValues: []float64{},
+	}
+	pie := vichart.PieChart{
+		Svg:           canvas,
+		Width:         400,
+		Height:        200,
+		PieValues:     []float64{},
+		Labels:        []string{},
+		LegendXOffset: 250,
+		GutterTop:     20,
+	}
+	for i := 0; i < len(bars.LabelsY); i++ {
+		val := rand.Intn(3000)
+		bars.BarValues = append(bars.BarValues, float64(val))
+		pie.PieValues = append(pie.PieValues, float64(val))
+		pie.Labels = append(pie.Labels, bars.LabelsY[i])
+	}
+
+	canvas.Start(950, 200)
+	vichart.Must(bars.DrawAt(0, 0))
+	vichart.Must(pie.DrawAt(550, 0))
+	canvas.End()
+}
+
+// themes draws the same chart with every built-in theme.
+func themes(w http.ResponseWriter, req *http.Request) {
+	w.Header().Set("Content-Type", "image/svg+xml")
+	canvas := svg.New(w)
+
+	canvas.Start(1600, 300)
+	for i, theme := range []*vichart.Theme{&vichart.LightTheme, &vichart.DarkTheme,
+		&vichart.PrintTheme, &vichart.ColorblindTheme} {
+		chart := vichart.VBarChart{
+			Svg:        canvas,
+			Width:      400,
+			Height:     300,
+			Theme:      theme,
+			BarValues:  []float64{3, 5, 2, 7, 4, 6},
+			LineValues: []float64{10, 12, 9, 15, 11, 14},
+			BarLegend:  "Sales",
+			LineLegend: "Visits",
+		}
+		vichart.Must(chart.DrawAt(i*400, 0))
+	}
+	canvas.End()
+}
+
+// dashboard draws grid of charts in single SVG document.
+func dashboard(w http.ResponseWriter, req *http.Request) {
+	w.Header().Set("Content-Type", "image/svg+xml")
+	canvas := svg.New(w)
+	rand.Seed(int64(time.Now().Second()))
+
+	bars := &vichart.VBarChart{
+		BarValues:  []float64{},
+		LineValues: []float64{},
+		BarLegend:  "Speed",
+		LineLegend: "Rpm",
+		GutterLeft: 50,
+	}
+	for i := 0; i < 12; i++ {
+		bars.BarValues = append(bars.BarValues, float64(rand.Intn(3000)))
+		bars.LineValues = append(bars.LineValues, float64(rand.Intn(3000)))
+	}
+	hbars := &vichart.HBarChart{
+		LabelsY:   []string{"Cost", "Priorities", "Timing", "Technology"},
+		BarValues: []float64{},
+	}
+	pie := &vichart.PieChart{
+		PieValues:     []float64{},
+		Labels:        []string{},
+		LegendXOffset: 220,
+		GutterTop:     20,
+	}
+	for i := 0; i < len(hbars.LabelsY); i++ {
+		val := float64(rand.Intn(3000))
+		hbars.BarValues = append(hbars.BarValues, val)
+		pie.PieValues = append(pie.PieValues, val)
+		pie.Labels = append(pie.Labels, hbars.LabelsY[i])
+	}
+
+	board := vichart.Dashboard{
+		Svg:    canvas,
+		Width:  900,
+		Height: 650,
+		Title:  "Dashboard",
+		Rows: []vichart.DashboardRow{
+			{Cells: []vichart.DashboardCell{{Chart: bars, Title: "Speed and Rpm"}}, Weight: 2},
+			{Cells: []vichart.DashboardCell{
+				{Chart: hbars, Title: "Priorities", Weight: 3},
+				{Chart: pie, Title: "Share", Weight: 2},
+			}},
+		},
+	}
+	vichart.Must(board.Draw())
+}